@@ -1,44 +1,79 @@
 package app
 
 import (
+	"context"
+	"fmt"
 	"fourth-exam/user-service-evrone/internal/app"
+	"fourth-exam/user-service-evrone/internal/delivery/kafka/handlers"
 	"fourth-exam/user-service-evrone/internal/pkg/config"
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
-)
-
-const (
-	USER_CREATE_CONSUMER = "user_create_consumer"
+	"go.uber.org/zap"
 )
 
 var consumerCmd = &cobra.Command{
-	Use:   "consumer", // command name that we will use to invoke this command 'go run cmd/main.go consumer ...'
-	Short: "To run consumer give the name followed by arguments consumer", // example usage of this command: 'go run cmd/main.go help'
-	Long: `Example : 
-		go run cmd/main.go consumer name_of_consumer`, // example usage of this command: 'go run cmd/main.go help consumer'
-	Args: cobra.ExactArgs(1), // number of arguments the command expects
-
-	Run: func(cmd *cobra.Command, args []string) { // this function will be executed when this command is invoked
-		consumerName := args[0] // extracts the first argument from the 'args' slice
-
-		switch consumerName { // switch statement based on consumerNames 
-		case USER_CREATE_CONSUMER:
-			UserCreateConsumerRun()
-		default:
-			log.Fatalf("No consumer with name '%s'", consumerName)
+	Use:   "consumer",                            // command name that we will use to invoke this command 'go run cmd/main.go consumer ...'
+	Short: "Lists and runs registered consumers", // example usage of this command: 'go run cmd/main.go help'
+	Long: `Example :
+		go run cmd/main.go consumer list
+		go run cmd/main.go consumer run name_of_consumer [name_of_consumer...]`, // example usage of this command: 'go run cmd/main.go help consumer'
+	Args: cobra.MinimumNArgs(1), // number of arguments the command expects
+
+	// 'consumer name_of_consumer' is kept as a shorthand of 'consumer run name_of_consumer'
+	Run: func(cmd *cobra.Command, args []string) {
+		ConsumersRun(args)
+	},
+}
+
+var consumerListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists registered consumers with their topic and group id",
+	Args:  cobra.NoArgs,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		config := config.New()
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tALIASES\tTOPIC\tGROUP ID\tSTART OFFSET\tDESCRIPTION")
+		for _, registration := range handlers.Consumers() {
+			settings := registration.Resolve(config)
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", registration.Name, strings.Join(registration.Aliases, ","), settings.Topic, settings.GroupID, settings.StartOffset, registration.Description)
 		}
+		w.Flush()
+	},
+}
+
+var consumerRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Runs the named consumers in one process",
+	Long: `Example :
+		go run cmd/main.go consumer run user_commands`,
+	Args: cobra.MinimumNArgs(1),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		ConsumersRun(args)
 	},
 }
 
 func init() {
+	consumerCmd.AddCommand(consumerListCmd, consumerRunCmd)
 	rootCmd.AddCommand(consumerCmd)
 }
 
-func UserCreateConsumerRun() {
+// ConsumersRun runs the named consumers until SIGINT or SIGTERM
+func ConsumersRun(names []string) {
+	for _, name := range names {
+		if _, ok := handlers.Lookup(name); !ok {
+			log.Fatalf("No consumer with name '%s'", name)
+		}
+	}
+
 	config := config.New()
 
 	app, err := app.NewUserConsumer(config)
@@ -46,16 +81,29 @@ func UserCreateConsumerRun() {
 		log.Fatal(err)
 	}
 
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	errs := make(chan error, 1)
 	go func() {
-		app.Run()
+		errs <- app.Run(ctx, names)
 	}()
 
-	sigs := make(chan os.Signal, 1)
-	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
-	<-sigs
+	select {
+	case err := <-errs:
+		// consumption stopped without a signal
+		app.Logger.Error("consumers stopped", zap.Strings("names", names), zap.Error(err))
+		app.Close()
+		os.Exit(1)
+	case <-ctx.Done():
+	}
 
-	app.Logger.Info("user consumer stops")
+	app.Logger.Info("consumers stop", zap.Strings("names", names))
 
-	// stop app
+	// stop app, in-flight messages are drained first
 	app.Close()
+	if err := <-errs; err != nil {
+		app.Logger.Error("consumers stopped with error", zap.Strings("names", names), zap.Error(err))
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"fourth-exam/user-service-evrone/internal/app"
+	"fourth-exam/user-service-evrone/internal/pkg/config"
+	"log"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var inboxPruneCmd = &cobra.Command{
+	Use:   "inbox-prune",
+	Short: "Deletes ids of consumed messages processed longer than the retention period",
+	Long: `Retention must be at least as long as retention of the consumed topics,
+otherwise a redelivered message is processed again.
+
+Example :
+		go run cmd/main.go inbox-prune --retention 336h`,
+	Args: cobra.NoArgs,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		config := config.New()
+
+		retention, err := cmd.Flags().GetString("retention")
+		if err != nil {
+			log.Fatal(err)
+		}
+		if retention == "" {
+			retention = config.Inbox.Retention
+		}
+
+		duration, err := time.ParseDuration(retention)
+		if err != nil {
+			log.Fatalf("invalid retention '%s': %v", retention, err)
+		}
+
+		app, err := app.NewInboxPrune(config)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		pruned, err := app.Run(duration)
+		if err != nil {
+			app.Logger.Error("error while pruning processed messages", zap.Error(err))
+			app.Close()
+			os.Exit(1)
+		}
+
+		app.Logger.Info("pruned processed messages", zap.Int64("count", pruned), zap.Duration("retention", duration))
+		app.Close()
+	},
+}
+
+func init() {
+	inboxPruneCmd.Flags().String("retention", "", "how long ids of processed messages are kept, defaults to INBOX_RETENTION")
+	rootCmd.AddCommand(inboxPruneCmd)
+}
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"context"
+	"fourth-exam/user-service-evrone/internal/app"
+	"fourth-exam/user-service-evrone/internal/pkg/config"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var outboxRelayCmd = &cobra.Command{
+	Use:   "outbox-relay",
+	Short: "Publishes user events written to the outbox to kafka",
+	Long: `Example :
+		go run cmd/main.go outbox-relay`,
+	Args: cobra.NoArgs,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		config := config.New()
+
+		app, err := app.NewOutboxRelay(config)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
+		if err := app.Run(ctx); err != nil {
+			app.Logger.Error("error while relaying outbox", zap.Error(err))
+			app.Close()
+			os.Exit(1)
+		}
+
+		app.Logger.Info("outbox relay stops")
+		app.Close()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(outboxRelayCmd)
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"fourth-exam/user-service-evrone/internal/app"
+	"fourth-exam/user-service-evrone/internal/pkg/config"
+	"log"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var outboxPruneCmd = &cobra.Command{
+	Use:   "outbox-prune",
+	Short: "Deletes user events of the outbox sent longer than the retention period",
+	Long: `Unsent events are kept whatever their age.
+
+Example :
+		go run cmd/main.go outbox-prune --retention 168h`,
+	Args: cobra.NoArgs,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		config := config.New()
+
+		retention, err := cmd.Flags().GetString("retention")
+		if err != nil {
+			log.Fatal(err)
+		}
+		if retention == "" {
+			retention = config.Outbox.Retention
+		}
+
+		duration, err := time.ParseDuration(retention)
+		if err != nil {
+			log.Fatalf("invalid retention '%s': %v", retention, err)
+		}
+
+		app, err := app.NewOutboxPrune(config)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		pruned, err := app.Run(duration)
+		if err != nil {
+			app.Logger.Error("error while pruning sent events", zap.Error(err))
+			app.Close()
+			os.Exit(1)
+		}
+
+		app.Logger.Info("pruned sent events", zap.Int64("count", pruned), zap.Duration("retention", duration))
+		app.Close()
+	},
+}
+
+func init() {
+	outboxPruneCmd.Flags().String("retention", "", "how long sent events are kept, defaults to OUTBOX_RETENTION")
+	rootCmd.AddCommand(outboxPruneCmd)
+}
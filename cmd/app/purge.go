@@ -0,0 +1,57 @@
+package app
+
+import (
+	"fourth-exam/user-service-evrone/internal/app"
+	"fourth-exam/user-service-evrone/internal/pkg/config"
+	"log"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var purgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Hard deletes users that were soft deleted longer than the retention period",
+	Long: `Example :
+		go run cmd/main.go purge --retention 720h`,
+	Args: cobra.NoArgs,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		config := config.New()
+
+		retention, err := cmd.Flags().GetString("retention")
+		if err != nil {
+			log.Fatal(err)
+		}
+		if retention == "" {
+			retention = config.Purge.Retention
+		}
+
+		duration, err := time.ParseDuration(retention)
+		if err != nil {
+			log.Fatalf("invalid retention '%s': %v", retention, err)
+		}
+
+		app, err := app.NewUserPurge(config)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		purged, err := app.Run(duration)
+		if err != nil {
+			app.Logger.Error("error while purging users", zap.Error(err))
+			app.Close()
+			os.Exit(1)
+		}
+
+		app.Logger.Info("purged soft deleted users", zap.Int64("count", purged), zap.Duration("retention", duration))
+		app.Close()
+	},
+}
+
+func init() {
+	purgeCmd.Flags().String("retention", "", "how long soft deleted users are kept, defaults to PURGE_RETENTION")
+	rootCmd.AddCommand(purgeCmd)
+}
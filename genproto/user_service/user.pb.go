@@ -6,6 +6,7 @@ package user
 import (
 	context "context"
 	fmt "fmt"
+	types "github.com/gogo/protobuf/types"
 	proto "github.com/golang/protobuf/proto"
 	empty "github.com/golang/protobuf/ptypes/empty"
 	grpc "google.golang.org/grpc"
@@ -28,18 +29,21 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type User struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id"`
-	Username             string   `protobuf:"bytes,2,opt,name=username,proto3" json:"username"`
-	Email                string   `protobuf:"bytes,3,opt,name=email,proto3" json:"email"`
-	Password             string   `protobuf:"bytes,4,opt,name=password,proto3" json:"password"`
-	FirstName            string   `protobuf:"bytes,5,opt,name=first_name,json=firstName,proto3" json:"first_name"`
-	LastName             string   `protobuf:"bytes,6,opt,name=last_name,json=lastName,proto3" json:"last_name"`
-	Bio                  string   `protobuf:"bytes,7,opt,name=bio,proto3" json:"bio"`
-	Website              string   `protobuf:"bytes,8,opt,name=website,proto3" json:"website"`
-	CreatedAt            string   `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at"`
-	UpdatedAt            string   `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at"`
-	IsActive             bool     `protobuf:"varint,11,opt,name=is_active,json=isActive,proto3" json:"is_active"`
-	RefreshToken         string   `protobuf:"bytes,12,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token"`
+	Id           string `protobuf:"bytes,1,opt,name=id,proto3" json:"id"`
+	Username     string `protobuf:"bytes,2,opt,name=username,proto3" json:"username"`
+	Email        string `protobuf:"bytes,3,opt,name=email,proto3" json:"email"`
+	Password     string `protobuf:"bytes,4,opt,name=password,proto3" json:"password"`
+	FirstName    string `protobuf:"bytes,5,opt,name=first_name,json=firstName,proto3" json:"first_name"`
+	LastName     string `protobuf:"bytes,6,opt,name=last_name,json=lastName,proto3" json:"last_name"`
+	Bio          string `protobuf:"bytes,7,opt,name=bio,proto3" json:"bio"`
+	Website      string `protobuf:"bytes,8,opt,name=website,proto3" json:"website"`
+	CreatedAt    string `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at"`
+	UpdatedAt    string `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at"`
+	IsActive     bool   `protobuf:"varint,11,opt,name=is_active,json=isActive,proto3" json:"is_active"`
+	RefreshToken string `protobuf:"bytes,12,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token"`
+	// etag of the user as returned by Get, when set Update fails with ABORTED
+	// if the user was changed since
+	Etag                 string   `protobuf:"bytes,13,opt,name=etag,proto3" json:"etag"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -162,10 +166,76 @@ func (m *User) GetRefreshToken() string {
 	return ""
 }
 
+func (m *User) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+type UpdateUserReq struct {
+	User *User `protobuf:"bytes,1,opt,name=user,proto3" json:"user"`
+	// paths of user to write: username, email, first_name, last_name, bio,
+	// website, is_active. When empty only the non-empty fields are written
+	UpdateMask           *types.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *UpdateUserReq) Reset()         { *m = UpdateUserReq{} }
+func (m *UpdateUserReq) String() string { return proto.CompactTextString(m) }
+func (*UpdateUserReq) ProtoMessage()    {}
+func (*UpdateUserReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_749038872b9165fb, []int{1}
+}
+func (m *UpdateUserReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UpdateUserReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UpdateUserReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *UpdateUserReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateUserReq.Merge(m, src)
+}
+func (m *UpdateUserReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *UpdateUserReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateUserReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateUserReq proto.InternalMessageInfo
+
+func (m *UpdateUserReq) GetUser() *User {
+	if m != nil {
+		return m.User
+	}
+	return nil
+}
+
+func (m *UpdateUserReq) GetUpdateMask() *types.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
 type GetRequest struct {
-	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id"`
-	Email                string   `protobuf:"bytes,2,opt,name=email,proto3" json:"email"`
-	Username             string   `protobuf:"bytes,3,opt,name=username,proto3" json:"username"`
+	UserId   string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id"`
+	Email    string `protobuf:"bytes,2,opt,name=email,proto3" json:"email"`
+	Username string `protobuf:"bytes,3,opt,name=username,proto3" json:"username"`
+	// expected etag for Delete, ignored by other calls
+	Etag                 string   `protobuf:"bytes,4,opt,name=etag,proto3" json:"etag"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -175,7 +245,7 @@ func (m *GetRequest) Reset()         { *m = GetRequest{} }
 func (m *GetRequest) String() string { return proto.CompactTextString(m) }
 func (*GetRequest) ProtoMessage()    {}
 func (*GetRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_749038872b9165fb, []int{1}
+	return fileDescriptor_749038872b9165fb, []int{2}
 }
 func (m *GetRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -225,11 +295,27 @@ func (m *GetRequest) GetUsername() string {
 	return ""
 }
 
+func (m *GetRequest) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
 type GetListFilter struct {
-	Page                 int64    `protobuf:"varint,1,opt,name=page,proto3" json:"page"`
-	Limit                int64    `protobuf:"varint,2,opt,name=limit,proto3" json:"limit"`
-	OrderBy              string   `protobuf:"bytes,3,opt,name=orderBy,proto3" json:"orderBy"`
-	IsActive             bool     `protobuf:"varint,4,opt,name=is_active,json=isActive,proto3" json:"is_active"`
+	Page           int64  `protobuf:"varint,1,opt,name=page,proto3" json:"page"`
+	Limit          int64  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit"`
+	OrderBy        string `protobuf:"bytes,3,opt,name=orderBy,proto3" json:"orderBy"`
+	IncludeDeleted bool   `protobuf:"varint,5,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted"`
+	// when unset users are listed regardless of activity
+	IsActive *types.BoolValue `protobuf:"bytes,6,opt,name=is_active,json=isActive,proto3" json:"is_active"`
+	// RFC3339 bounds of created_at, both exclusive
+	CreatedFrom string `protobuf:"bytes,7,opt,name=created_from,json=createdFrom,proto3" json:"created_from"`
+	CreatedTo   string `protobuf:"bytes,8,opt,name=created_to,json=createdTo,proto3" json:"created_to"`
+	// case-insensitive match against username, first_name, last_name and email
+	Search string `protobuf:"bytes,9,opt,name=search,proto3" json:"search"`
+	// next_page_token of the previous response, page is ignored when set
+	PageToken            string   `protobuf:"bytes,10,opt,name=page_token,json=pageToken,proto3" json:"page_token"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -239,7 +325,7 @@ func (m *GetListFilter) Reset()         { *m = GetListFilter{} }
 func (m *GetListFilter) String() string { return proto.CompactTextString(m) }
 func (*GetListFilter) ProtoMessage()    {}
 func (*GetListFilter) Descriptor() ([]byte, []int) {
-	return fileDescriptor_749038872b9165fb, []int{2}
+	return fileDescriptor_749038872b9165fb, []int{3}
 }
 func (m *GetListFilter) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -289,13 +375,48 @@ func (m *GetListFilter) GetOrderBy() string {
 	return ""
 }
 
-func (m *GetListFilter) GetIsActive() bool {
+func (m *GetListFilter) GetIncludeDeleted() bool {
 	if m != nil {
-		return m.IsActive
+		return m.IncludeDeleted
 	}
 	return false
 }
 
+func (m *GetListFilter) GetIsActive() *types.BoolValue {
+	if m != nil {
+		return m.IsActive
+	}
+	return nil
+}
+
+func (m *GetListFilter) GetCreatedFrom() string {
+	if m != nil {
+		return m.CreatedFrom
+	}
+	return ""
+}
+
+func (m *GetListFilter) GetCreatedTo() string {
+	if m != nil {
+		return m.CreatedTo
+	}
+	return ""
+}
+
+func (m *GetListFilter) GetSearch() string {
+	if m != nil {
+		return m.Search
+	}
+	return ""
+}
+
+func (m *GetListFilter) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
 type CheckFieldReq struct {
 	Field                string   `protobuf:"bytes,1,opt,name=field,proto3" json:"field"`
 	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value"`
@@ -308,7 +429,7 @@ func (m *CheckFieldReq) Reset()         { *m = CheckFieldReq{} }
 func (m *CheckFieldReq) String() string { return proto.CompactTextString(m) }
 func (*CheckFieldReq) ProtoMessage()    {}
 func (*CheckFieldReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_749038872b9165fb, []int{3}
+	return fileDescriptor_749038872b9165fb, []int{4}
 }
 func (m *CheckFieldReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -351,6 +472,7 @@ func (m *CheckFieldReq) GetValue() string {
 	return ""
 }
 
+// Status reports whether the checked value is still available.
 type Status struct {
 	Status               bool     `protobuf:"varint,1,opt,name=status,proto3" json:"status"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -362,7 +484,7 @@ func (m *Status) Reset()         { *m = Status{} }
 func (m *Status) String() string { return proto.CompactTextString(m) }
 func (*Status) ProtoMessage()    {}
 func (*Status) Descriptor() ([]byte, []int) {
-	return fileDescriptor_749038872b9165fb, []int{4}
+	return fileDescriptor_749038872b9165fb, []int{5}
 }
 func (m *Status) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -410,7 +532,7 @@ func (m *UpdateRefreshReq) Reset()         { *m = UpdateRefreshReq{} }
 func (m *UpdateRefreshReq) String() string { return proto.CompactTextString(m) }
 func (*UpdateRefreshReq) ProtoMessage()    {}
 func (*UpdateRefreshReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_749038872b9165fb, []int{5}
+	return fileDescriptor_749038872b9165fb, []int{6}
 }
 func (m *UpdateRefreshReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -453,6 +575,62 @@ func (m *UpdateRefreshReq) GetRefreshToken() string {
 	return ""
 }
 
+type VerifyCredentialsReq struct {
+	// username or email
+	Login                string   `protobuf:"bytes,1,opt,name=login,proto3" json:"login"`
+	Password             string   `protobuf:"bytes,2,opt,name=password,proto3" json:"password"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VerifyCredentialsReq) Reset()         { *m = VerifyCredentialsReq{} }
+func (m *VerifyCredentialsReq) String() string { return proto.CompactTextString(m) }
+func (*VerifyCredentialsReq) ProtoMessage()    {}
+func (*VerifyCredentialsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_749038872b9165fb, []int{7}
+}
+func (m *VerifyCredentialsReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *VerifyCredentialsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_VerifyCredentialsReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *VerifyCredentialsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyCredentialsReq.Merge(m, src)
+}
+func (m *VerifyCredentialsReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *VerifyCredentialsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyCredentialsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VerifyCredentialsReq proto.InternalMessageInfo
+
+func (m *VerifyCredentialsReq) GetLogin() string {
+	if m != nil {
+		return m.Login
+	}
+	return ""
+}
+
+func (m *VerifyCredentialsReq) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
 type Comment struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id"`
 	PostId               string   `protobuf:"bytes,2,opt,name=post_id,json=postId,proto3" json:"post_id"`
@@ -470,7 +648,7 @@ func (m *Comment) Reset()         { *m = Comment{} }
 func (m *Comment) String() string { return proto.CompactTextString(m) }
 func (*Comment) ProtoMessage()    {}
 func (*Comment) Descriptor() ([]byte, []int) {
-	return fileDescriptor_749038872b9165fb, []int{6}
+	return fileDescriptor_749038872b9165fb, []int{8}
 }
 func (m *Comment) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -569,7 +747,7 @@ func (m *Post) Reset()         { *m = Post{} }
 func (m *Post) String() string { return proto.CompactTextString(m) }
 func (*Post) ProtoMessage()    {}
 func (*Post) Descriptor() ([]byte, []int) {
-	return fileDescriptor_749038872b9165fb, []int{7}
+	return fileDescriptor_749038872b9165fb, []int{9}
 }
 func (m *Post) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -689,6 +867,8 @@ type UserModel struct {
 	IsActive             bool     `protobuf:"varint,11,opt,name=is_active,json=isActive,proto3" json:"is_active"`
 	RefreshToken         string   `protobuf:"bytes,12,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token"`
 	Posts                []*Post  `protobuf:"bytes,13,rep,name=posts,proto3" json:"posts"`
+	DeletedAt            string   `protobuf:"bytes,14,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at"`
+	Etag                 string   `protobuf:"bytes,15,opt,name=etag,proto3" json:"etag"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -698,7 +878,7 @@ func (m *UserModel) Reset()         { *m = UserModel{} }
 func (m *UserModel) String() string { return proto.CompactTextString(m) }
 func (*UserModel) ProtoMessage()    {}
 func (*UserModel) Descriptor() ([]byte, []int) {
-	return fileDescriptor_749038872b9165fb, []int{8}
+	return fileDescriptor_749038872b9165fb, []int{10}
 }
 func (m *UserModel) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -818,19 +998,36 @@ func (m *UserModel) GetPosts() []*Post {
 	return nil
 }
 
+func (m *UserModel) GetDeletedAt() string {
+	if m != nil {
+		return m.DeletedAt
+	}
+	return ""
+}
+
+func (m *UserModel) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
 type Users struct {
-	Count                int64        `protobuf:"varint,1,opt,name=count,proto3" json:"count"`
-	Users                []*UserModel `protobuf:"bytes,2,rep,name=users,proto3" json:"users"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	// total number of users matching the filter, not the page size
+	Count int64        `protobuf:"varint,1,opt,name=count,proto3" json:"count"`
+	Users []*UserModel `protobuf:"bytes,2,rep,name=users,proto3" json:"users"`
+	// empty when there are no more users
+	NextPageToken        string   `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Users) Reset()         { *m = Users{} }
 func (m *Users) String() string { return proto.CompactTextString(m) }
 func (*Users) ProtoMessage()    {}
 func (*Users) Descriptor() ([]byte, []int) {
-	return fileDescriptor_749038872b9165fb, []int{9}
+	return fileDescriptor_749038872b9165fb, []int{11}
 }
 func (m *Users) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -873,71 +1070,246 @@ func (m *Users) GetUsers() []*UserModel {
 	return nil
 }
 
+func (m *Users) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+// UserEvent is the envelope of messages consumed from the user topic
+type UserEvent struct {
+	// user.create, user.update, user.delete, user.activate or user.deactivate
+	EventType string `protobuf:"bytes,1,opt,name=event_type,json=eventType,proto3" json:"event_type"`
+	Version   int32  `protobuf:"varint,2,opt,name=version,proto3" json:"version"`
+	// RFC3339 time the event happened at
+	OccurredAt           string            `protobuf:"bytes,3,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at"`
+	Payload              *UserEventPayload `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *UserEvent) Reset()         { *m = UserEvent{} }
+func (m *UserEvent) String() string { return proto.CompactTextString(m) }
+func (*UserEvent) ProtoMessage()    {}
+func (*UserEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_749038872b9165fb, []int{12}
+}
+func (m *UserEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UserEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UserEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *UserEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserEvent.Merge(m, src)
+}
+func (m *UserEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *UserEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UserEvent proto.InternalMessageInfo
+
+func (m *UserEvent) GetEventType() string {
+	if m != nil {
+		return m.EventType
+	}
+	return ""
+}
+
+func (m *UserEvent) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *UserEvent) GetOccurredAt() string {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return ""
+}
+
+func (m *UserEvent) GetPayload() *UserEventPayload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+type UserEventPayload struct {
+	// user to create or to update
+	User *User `protobuf:"bytes,1,opt,name=user,proto3" json:"user"`
+	// paths of user to write on update, see UpdateUserReq
+	UpdateMask *types.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask"`
+	// user to delete, activate or deactivate
+	UserId               string   `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UserEventPayload) Reset()         { *m = UserEventPayload{} }
+func (m *UserEventPayload) String() string { return proto.CompactTextString(m) }
+func (*UserEventPayload) ProtoMessage()    {}
+func (*UserEventPayload) Descriptor() ([]byte, []int) {
+	return fileDescriptor_749038872b9165fb, []int{13}
+}
+func (m *UserEventPayload) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UserEventPayload) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UserEventPayload.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *UserEventPayload) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserEventPayload.Merge(m, src)
+}
+func (m *UserEventPayload) XXX_Size() int {
+	return m.Size()
+}
+func (m *UserEventPayload) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserEventPayload.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UserEventPayload proto.InternalMessageInfo
+
+func (m *UserEventPayload) GetUser() *User {
+	if m != nil {
+		return m.User
+	}
+	return nil
+}
+
+func (m *UserEventPayload) GetUpdateMask() *types.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
+func (m *UserEventPayload) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*User)(nil), "user.User")
+	proto.RegisterType((*UpdateUserReq)(nil), "user.UpdateUserReq")
 	proto.RegisterType((*GetRequest)(nil), "user.GetRequest")
 	proto.RegisterType((*GetListFilter)(nil), "user.GetListFilter")
 	proto.RegisterType((*CheckFieldReq)(nil), "user.CheckFieldReq")
 	proto.RegisterType((*Status)(nil), "user.Status")
 	proto.RegisterType((*UpdateRefreshReq)(nil), "user.UpdateRefreshReq")
+	proto.RegisterType((*VerifyCredentialsReq)(nil), "user.VerifyCredentialsReq")
 	proto.RegisterType((*Comment)(nil), "user.Comment")
 	proto.RegisterType((*Post)(nil), "user.Post")
 	proto.RegisterType((*UserModel)(nil), "user.UserModel")
 	proto.RegisterType((*Users)(nil), "user.Users")
+	proto.RegisterType((*UserEvent)(nil), "user.UserEvent")
+	proto.RegisterType((*UserEventPayload)(nil), "user.UserEventPayload")
 }
 
 func init() { proto.RegisterFile("user_service/user.proto", fileDescriptor_749038872b9165fb) }
 
 var fileDescriptor_749038872b9165fb = []byte{
-	// 765 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x55, 0xcf, 0x6e, 0x13, 0x3f,
-	0x10, 0xfe, 0xed, 0x6e, 0xb2, 0x49, 0x26, 0xcd, 0x8f, 0xc8, 0x54, 0xed, 0x2a, 0x15, 0x51, 0xb4,
-	0x08, 0x29, 0x5c, 0x52, 0x29, 0x1c, 0x39, 0xf5, 0x0f, 0xad, 0x2a, 0x01, 0xaa, 0xb6, 0x54, 0x1c,
-	0xa3, 0xcd, 0xee, 0x24, 0xb5, 0xb2, 0x89, 0xd3, 0xb5, 0xd3, 0xa8, 0x6f, 0xc2, 0x43, 0xf0, 0x1a,
-	0x48, 0x1c, 0x38, 0x70, 0xe5, 0x86, 0xca, 0x23, 0xf0, 0x02, 0x68, 0xec, 0xdd, 0xa4, 0x49, 0x5b,
-	0x2e, 0x70, 0xe4, 0xe6, 0x6f, 0x3e, 0xcf, 0xd8, 0xfe, 0xfc, 0x79, 0x0c, 0xdb, 0x33, 0x89, 0x69,
-	0x4f, 0x62, 0x7a, 0xc5, 0x23, 0xdc, 0x25, 0xd0, 0x99, 0xa6, 0x42, 0x09, 0x56, 0xa0, 0x71, 0x63,
-	0x67, 0x28, 0xc4, 0x30, 0xc1, 0x5d, 0x1d, 0xeb, 0xcf, 0x06, 0xbb, 0x38, 0x9e, 0xaa, 0x6b, 0x33,
-	0xc5, 0xff, 0x62, 0x43, 0xe1, 0x5c, 0x62, 0xca, 0xfe, 0x07, 0x9b, 0xc7, 0x9e, 0xd5, 0xb2, 0xda,
-	0x95, 0xc0, 0xe6, 0x31, 0x6b, 0x40, 0x99, 0xb2, 0x27, 0xe1, 0x18, 0x3d, 0x5b, 0x47, 0x17, 0x98,
-	0x6d, 0x42, 0x11, 0xc7, 0x21, 0x4f, 0x3c, 0x47, 0x13, 0x06, 0x50, 0xc6, 0x34, 0x94, 0x72, 0x2e,
-	0xd2, 0xd8, 0x2b, 0x98, 0x8c, 0x1c, 0xb3, 0x27, 0x00, 0x03, 0x9e, 0x4a, 0xd5, 0xd3, 0xf5, 0x8a,
-	0x9a, 0xad, 0xe8, 0xc8, 0x5b, 0x2a, 0xb8, 0x03, 0x95, 0x24, 0xcc, 0x59, 0xd7, 0xe4, 0x52, 0x40,
-	0x93, 0x75, 0x70, 0xfa, 0x5c, 0x78, 0x25, 0x1d, 0xa6, 0x21, 0xf3, 0xa0, 0x34, 0xc7, 0xbe, 0xe4,
-	0x0a, 0xbd, 0xb2, 0x8e, 0xe6, 0x90, 0xd6, 0x89, 0x52, 0x0c, 0x15, 0xc6, 0xbd, 0x50, 0x79, 0x15,
-	0xb3, 0x4e, 0x16, 0xd9, 0x53, 0x44, 0xcf, 0xa6, 0x71, 0x4e, 0x83, 0xa1, 0xb3, 0xc8, 0x9e, 0xa2,
-	0x6d, 0x70, 0xd9, 0x0b, 0x23, 0xc5, 0xaf, 0xd0, 0xab, 0xb6, 0xac, 0x76, 0x39, 0x28, 0x73, 0xb9,
-	0xa7, 0x31, 0x7b, 0x0a, 0xb5, 0x14, 0x07, 0x29, 0xca, 0x8b, 0x9e, 0x12, 0x23, 0x9c, 0x78, 0x1b,
-	0x3a, 0x7d, 0x23, 0x0b, 0xbe, 0xa3, 0x98, 0xff, 0x1e, 0xe0, 0x18, 0x55, 0x80, 0x97, 0x33, 0x94,
-	0x8a, 0x6d, 0x43, 0x49, 0x5f, 0xcd, 0x42, 0x58, 0x97, 0xe0, 0x49, 0xbc, 0x14, 0xd0, 0x5e, 0x13,
-	0x70, 0x21, 0xb9, 0xb3, 0x2a, 0xb9, 0x3f, 0x85, 0xda, 0x31, 0xaa, 0xd7, 0x5c, 0xaa, 0x23, 0x9e,
-	0x28, 0x4c, 0x19, 0x83, 0xc2, 0x34, 0x1c, 0xa2, 0x2e, 0xec, 0x04, 0x7a, 0x4c, 0x65, 0x13, 0x3e,
-	0xe6, 0x4a, 0x97, 0x75, 0x02, 0x03, 0x48, 0x2d, 0x91, 0xc6, 0x98, 0xee, 0x5f, 0x67, 0x55, 0x73,
-	0xb8, 0x7a, 0xde, 0xc2, 0xea, 0x79, 0xfd, 0x97, 0x50, 0x3b, 0xb8, 0xc0, 0x68, 0x74, 0xc4, 0x31,
-	0x89, 0x03, 0xbc, 0xa4, 0xea, 0x03, 0x1a, 0x67, 0x67, 0x31, 0x80, 0xa2, 0x57, 0x61, 0x32, 0xcb,
-	0x4d, 0x62, 0x80, 0xdf, 0x02, 0xf7, 0x4c, 0x85, 0x6a, 0x26, 0xd9, 0x16, 0xb8, 0x52, 0x8f, 0x74,
-	0x5a, 0x39, 0xc8, 0x90, 0x7f, 0x0a, 0xf5, 0x73, 0x2d, 0x7c, 0x60, 0xf4, 0xa3, 0x15, 0x1e, 0xd4,
-	0xeb, 0x8e, 0xf6, 0xf6, 0x3d, 0xda, 0x7f, 0xb2, 0xa0, 0x74, 0x20, 0xc6, 0x63, 0x9c, 0xa8, 0x3b,
-	0x6e, 0xde, 0x86, 0xd2, 0x54, 0x48, 0x45, 0x95, 0x4d, 0xaa, 0x4b, 0xf0, 0x24, 0xbe, 0xbd, 0xa4,
-	0xb3, 0xb2, 0xa4, 0x07, 0xa5, 0x48, 0x4c, 0x14, 0x4e, 0x54, 0x66, 0xe6, 0x1c, 0xae, 0x79, 0xac,
-	0xf8, 0x7b, 0x8f, 0xb9, 0xeb, 0x1e, 0x6b, 0x41, 0x51, 0xcc, 0x27, 0x98, 0x6a, 0x3f, 0x57, 0xbb,
-	0xd0, 0xd1, 0xef, 0x95, 0x9e, 0x60, 0x60, 0x08, 0xff, 0xa3, 0x0d, 0x85, 0x53, 0x21, 0xef, 0x3d,
-	0x44, 0xbe, 0x57, 0xfb, 0xa1, 0xbd, 0x3a, 0xab, 0x7b, 0xdd, 0x84, 0xa2, 0xe2, 0x2a, 0xc1, 0xec,
-	0x0c, 0x06, 0x18, 0x9f, 0x8c, 0x50, 0xea, 0xcd, 0x6b, 0x9f, 0x8c, 0x50, 0x92, 0xfd, 0x62, 0x2e,
-	0x0d, 0xe1, 0x6a, 0x62, 0x81, 0xf5, 0x2d, 0x73, 0x9c, 0x4b, 0xbd, 0x6b, 0x27, 0x30, 0x80, 0x32,
-	0xa2, 0x50, 0xe1, 0x50, 0xa4, 0xd7, 0xd9, 0x43, 0x5c, 0xe0, 0x3f, 0x7c, 0x89, 0xcf, 0xa1, 0x1c,
-	0x99, 0xab, 0x94, 0x5e, 0xb5, 0xe5, 0xb4, 0xab, 0xdd, 0x9a, 0x11, 0x2a, 0xbb, 0xe0, 0x60, 0x41,
-	0xfb, 0x3f, 0x6d, 0xa8, 0x90, 0x7c, 0x6f, 0x44, 0x8c, 0xc9, 0xbf, 0x36, 0xf6, 0x37, 0xda, 0x18,
-	0x99, 0x94, 0xde, 0x87, 0xf4, 0x6a, 0x5a, 0xfb, 0xcc, 0xa4, 0x64, 0xca, 0xc0, 0x10, 0xfe, 0x21,
-	0x14, 0x49, 0x74, 0xed, 0x8c, 0x48, 0xcc, 0x26, 0x2a, 0x6b, 0x44, 0x06, 0xb0, 0x67, 0x50, 0xa4,
-	0x14, 0xe9, 0xd9, 0xba, 0xc0, 0xa3, 0xa5, 0xcb, 0xf5, 0x35, 0x05, 0x86, 0xed, 0x7e, 0xb3, 0xa0,
-	0x4a, 0xc1, 0x33, 0xf3, 0x77, 0xb1, 0x16, 0xb8, 0x07, 0xfa, 0x94, 0xec, 0xd6, 0xbb, 0x68, 0xdc,
-	0x1a, 0xd3, 0x0c, 0xd3, 0x36, 0x1e, 0x9c, 0xd1, 0x06, 0xe7, 0x18, 0x15, 0xab, 0x9b, 0xd0, 0xb2,
-	0x1b, 0x37, 0xd6, 0x37, 0xc1, 0xba, 0xe0, 0x1e, 0x62, 0x82, 0x0a, 0xef, 0x99, 0xbc, 0xd5, 0x31,
-	0xbf, 0x66, 0x27, 0xff, 0x35, 0x3b, 0xaf, 0xe8, 0xd7, 0x64, 0x6d, 0x28, 0x50, 0x13, 0x66, 0x8f,
-	0x17, 0x19, 0xcb, 0x9e, 0xdc, 0xa8, 0x2e, 0x57, 0x90, 0xfb, 0xf5, 0xcf, 0x37, 0x4d, 0xeb, 0xeb,
-	0x4d, 0xd3, 0xfa, 0x7e, 0xd3, 0xb4, 0x3e, 0xfc, 0x68, 0xfe, 0xd7, 0x77, 0x75, 0xad, 0x17, 0xbf,
-	0x02, 0x00, 0x00, 0xff, 0xff, 0xa7, 0x25, 0xa5, 0xf6, 0xb0, 0x07, 0x00, 0x00,
+	// 1148 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x56, 0xcd, 0x6e, 0x23, 0x45,
+	0x10, 0xc6, 0x1e, 0x8f, 0x7f, 0xca, 0xf1, 0xc6, 0x34, 0x51, 0x76, 0xe4, 0x15, 0xc6, 0x0c, 0x02,
+	0x82, 0x84, 0x12, 0xe4, 0x3d, 0x70, 0xd8, 0x0b, 0xd9, 0xec, 0x26, 0x2c, 0x62, 0x51, 0x34, 0x9b,
+	0xdd, 0xab, 0x35, 0x99, 0x29, 0x3b, 0x2d, 0x8f, 0xdd, 0xde, 0xee, 0x76, 0x82, 0xdf, 0x80, 0x37,
+	0x80, 0x27, 0xe0, 0xc4, 0x91, 0x57, 0x40, 0xe2, 0xc8, 0x23, 0xac, 0xc2, 0x33, 0x70, 0x47, 0x5d,
+	0xdd, 0xe3, 0xdf, 0x18, 0x09, 0x01, 0x37, 0x6e, 0xfd, 0x55, 0x55, 0x4f, 0x55, 0xf7, 0xf7, 0x75,
+	0xd5, 0xc0, 0xfd, 0xa9, 0x42, 0xd9, 0x53, 0x28, 0xaf, 0x79, 0x82, 0x47, 0x06, 0x1c, 0x4e, 0xa4,
+	0xd0, 0x82, 0x95, 0xcc, 0xba, 0xf5, 0x60, 0x20, 0xc4, 0x20, 0xc3, 0x23, 0xb2, 0x5d, 0x4e, 0xfb,
+	0x47, 0x38, 0x9a, 0xe8, 0x99, 0x0d, 0x69, 0x75, 0xd6, 0x9d, 0x7d, 0x8e, 0x59, 0xda, 0x1b, 0xc5,
+	0x6a, 0xe8, 0x22, 0xda, 0xeb, 0x11, 0x37, 0x32, 0x9e, 0x4c, 0x50, 0x2a, 0xeb, 0x0f, 0xdf, 0x14,
+	0xa1, 0xf4, 0x52, 0xa1, 0x64, 0xf7, 0xa0, 0xc8, 0xd3, 0xa0, 0xd0, 0x29, 0x1c, 0xd4, 0xa2, 0x22,
+	0x4f, 0x59, 0x0b, 0xaa, 0x26, 0xff, 0x38, 0x1e, 0x61, 0x50, 0x24, 0xeb, 0x1c, 0xb3, 0x3d, 0xf0,
+	0x71, 0x14, 0xf3, 0x2c, 0xf0, 0xc8, 0x61, 0x81, 0xd9, 0x31, 0x89, 0x95, 0xba, 0x11, 0x32, 0x0d,
+	0x4a, 0x76, 0x47, 0x8e, 0xd9, 0xbb, 0x00, 0x7d, 0x2e, 0x95, 0xee, 0xd1, 0xf7, 0x7c, 0xf2, 0xd6,
+	0xc8, 0xf2, 0x8d, 0xf9, 0xe0, 0x03, 0xa8, 0x65, 0x71, 0xee, 0x2d, 0xdb, 0xbd, 0x59, 0xec, 0x9c,
+	0x4d, 0xf0, 0x2e, 0xb9, 0x08, 0x2a, 0x64, 0x36, 0x4b, 0x16, 0x40, 0xe5, 0x06, 0x2f, 0x15, 0xd7,
+	0x18, 0x54, 0xc9, 0x9a, 0x43, 0x93, 0x27, 0x91, 0x18, 0x6b, 0x4c, 0x7b, 0xb1, 0x0e, 0x6a, 0x36,
+	0x8f, 0xb3, 0x1c, 0x6b, 0xe3, 0x9e, 0x4e, 0xd2, 0xdc, 0x0d, 0xd6, 0xed, 0x2c, 0xc7, 0xda, 0x94,
+	0xc1, 0x55, 0x2f, 0x4e, 0x34, 0xbf, 0xc6, 0xa0, 0xde, 0x29, 0x1c, 0x54, 0xa3, 0x2a, 0x57, 0xc7,
+	0x84, 0xd9, 0x07, 0xd0, 0x90, 0xd8, 0x97, 0xa8, 0xae, 0x7a, 0x5a, 0x0c, 0x71, 0x1c, 0xec, 0xd0,
+	0xf6, 0x1d, 0x67, 0xbc, 0x30, 0x36, 0xc6, 0xa0, 0x84, 0x3a, 0x1e, 0x04, 0x0d, 0xf2, 0xd1, 0x3a,
+	0xcc, 0xa0, 0xf1, 0x92, 0x52, 0x98, 0x7b, 0x8e, 0xf0, 0x35, 0x6b, 0x03, 0x51, 0x4b, 0x97, 0x5d,
+	0xef, 0xc2, 0x21, 0x71, 0x4e, 0x4e, 0xb2, 0xb3, 0x47, 0x50, 0xb7, 0x35, 0x11, 0x91, 0x74, 0xfb,
+	0xf5, 0x6e, 0xeb, 0xd0, 0x32, 0x79, 0x98, 0x33, 0x79, 0x78, 0x6a, 0xb8, 0x7e, 0x1e, 0xab, 0x61,
+	0xe4, 0x0e, 0x65, 0xd6, 0xe1, 0x10, 0xe0, 0x0c, 0x75, 0x84, 0xaf, 0xa7, 0xa8, 0x34, 0xbb, 0x0f,
+	0x15, 0x92, 0xd7, 0x9c, 0xda, 0xb2, 0x81, 0xcf, 0xd2, 0x05, 0x85, 0xc5, 0x35, 0x0a, 0xe7, 0xa4,
+	0x7b, 0x6b, 0xa4, 0xe7, 0x47, 0x2b, 0x2d, 0x1d, 0xed, 0xe7, 0x22, 0x34, 0xce, 0x50, 0x7f, 0xcd,
+	0x95, 0x3e, 0xe5, 0x99, 0x46, 0x69, 0xa2, 0x26, 0xf1, 0x00, 0x29, 0x9b, 0x17, 0xd1, 0xda, 0xe4,
+	0xca, 0xf8, 0x88, 0x6b, 0xca, 0xe5, 0x45, 0x16, 0x18, 0x12, 0x85, 0x4c, 0x51, 0x3e, 0x9e, 0xb9,
+	0x54, 0x39, 0x64, 0x1f, 0xc3, 0x2e, 0x1f, 0x27, 0xd9, 0x34, 0xc5, 0x5e, 0x8a, 0x19, 0x6a, 0x4c,
+	0x49, 0x31, 0xd5, 0xe8, 0x9e, 0x33, 0x3f, 0xb1, 0x56, 0xf6, 0xf9, 0x32, 0x5f, 0xe5, 0x2d, 0xd7,
+	0xf4, 0x58, 0x88, 0xec, 0x55, 0x9c, 0x4d, 0x71, 0x89, 0xcb, 0xf7, 0x61, 0x27, 0x97, 0x49, 0x5f,
+	0x8a, 0x91, 0xd3, 0x56, 0xdd, 0xd9, 0x4e, 0xa5, 0x18, 0x2d, 0x2b, 0x49, 0x8b, 0xa0, 0xba, 0xa2,
+	0xa4, 0x0b, 0xc1, 0xf6, 0xa1, 0xac, 0x30, 0x96, 0xc9, 0x95, 0x13, 0x99, 0x43, 0x66, 0x9b, 0x39,
+	0xb3, 0x93, 0x88, 0x53, 0x98, 0xb1, 0x90, 0x3e, 0xbe, 0x2a, 0x55, 0x4b, 0x4d, 0x3f, 0x7c, 0x04,
+	0x8d, 0x93, 0x2b, 0x4c, 0x86, 0xc4, 0xa0, 0x51, 0xc4, 0x1e, 0xf8, 0xf4, 0x72, 0x1d, 0x49, 0x16,
+	0x18, 0xeb, 0xb5, 0x29, 0x3c, 0xe7, 0x88, 0x40, 0xd8, 0x81, 0xf2, 0x0b, 0x1d, 0xeb, 0xa9, 0xa2,
+	0x1a, 0x68, 0x45, 0xdb, 0xaa, 0x91, 0x43, 0xe1, 0x39, 0x34, 0xad, 0xe0, 0x22, 0x2b, 0x4d, 0x93,
+	0x61, 0xab, 0x10, 0x36, 0x64, 0x5d, 0xdc, 0x94, 0x75, 0xf8, 0x25, 0xec, 0xbd, 0x42, 0xc9, 0xfb,
+	0xb3, 0x13, 0x89, 0x29, 0x8e, 0x35, 0x8f, 0x33, 0xe5, 0xea, 0xce, 0xc4, 0x80, 0x8f, 0xf3, 0xba,
+	0x09, 0xac, 0x34, 0x82, 0xe2, 0x6a, 0x23, 0x08, 0x7f, 0x29, 0x40, 0xe5, 0x44, 0x8c, 0x46, 0x38,
+	0xd6, 0x1b, 0x2d, 0xe7, 0x3e, 0x54, 0x26, 0x42, 0xe9, 0x1e, 0xcf, 0xb7, 0x95, 0x0d, 0x7c, 0x96,
+	0x2e, 0x17, 0xef, 0xad, 0x14, 0x1f, 0x40, 0x25, 0x11, 0x63, 0x8d, 0x63, 0xed, 0x64, 0x99, 0xc3,
+	0xb5, 0x46, 0xe0, 0xff, 0x75, 0x23, 0x28, 0xaf, 0x37, 0x82, 0x0e, 0xf8, 0xe2, 0x66, 0x8c, 0x32,
+	0xa8, 0x6c, 0x3c, 0x51, 0xeb, 0x08, 0x7f, 0x2a, 0x42, 0xe9, 0x5c, 0xa8, 0x3b, 0x0f, 0x91, 0xd7,
+	0x5a, 0xdc, 0x56, 0xab, 0xb7, 0x5a, 0xeb, 0x1e, 0xf8, 0x9a, 0xeb, 0x0c, 0xdd, 0x19, 0x2c, 0xb0,
+	0xaf, 0x66, 0x88, 0x2a, 0xf0, 0xf3, 0x57, 0x33, 0x44, 0x65, 0xee, 0x36, 0xe5, 0xca, 0x3a, 0xca,
+	0xe4, 0x98, 0x63, 0xd2, 0x0b, 0xc7, 0x1b, 0x45, 0x55, 0x7b, 0x91, 0x05, 0x66, 0x47, 0x12, 0x6b,
+	0x1c, 0x08, 0x39, 0x73, 0x32, 0x9e, 0xe3, 0x7f, 0xd8, 0x2e, 0x3f, 0x81, 0x6a, 0x62, 0xa9, 0x54,
+	0x41, 0xbd, 0xe3, 0x1d, 0xd4, 0xbb, 0x0d, 0x7b, 0x51, 0x8e, 0xe0, 0x68, 0xee, 0x0e, 0x7f, 0xf4,
+	0xa0, 0x66, 0xae, 0xef, 0xb9, 0x48, 0x31, 0xfb, 0x7f, 0xd6, 0xfc, 0x2b, 0xb3, 0xa6, 0x03, 0xbe,
+	0x79, 0x1f, 0x2a, 0x68, 0x74, 0xbc, 0x85, 0x48, 0x8d, 0x28, 0x23, 0xeb, 0x30, 0x25, 0xb8, 0x06,
+	0x6a, 0x4a, 0xb8, 0x67, 0x4b, 0x70, 0x96, 0x63, 0x3d, 0xef, 0xe8, 0xbb, 0x2b, 0xc3, 0xca, 0x37,
+	0x3c, 0x91, 0x98, 0x12, 0x31, 0x1d, 0x6b, 0xd7, 0xc9, 0x2d, 0x60, 0x1f, 0x82, 0x6f, 0xb2, 0xa8,
+	0xa0, 0x48, 0x39, 0x77, 0x17, 0x0f, 0x83, 0x98, 0x8d, 0xac, 0x97, 0x7d, 0x04, 0xbb, 0x63, 0xfc,
+	0x56, 0xf7, 0x96, 0x5a, 0xa1, 0xa5, 0xaf, 0x61, 0xcc, 0xe7, 0x79, 0x3b, 0x0c, 0xbf, 0x2f, 0x58,
+	0x59, 0x3c, 0xbd, 0x76, 0x6f, 0x16, 0xcd, 0xa2, 0xa7, 0x67, 0x13, 0x74, 0xf2, 0xa8, 0x91, 0xe5,
+	0x62, 0x36, 0x41, 0xc3, 0xc4, 0x35, 0x4a, 0xc5, 0x85, 0xed, 0x51, 0x7e, 0x94, 0x43, 0xf6, 0x1e,
+	0xd4, 0x45, 0x92, 0x4c, 0xa5, 0xb4, 0x07, 0xb5, 0xa9, 0x20, 0x37, 0x1d, 0x6b, 0xf6, 0x19, 0x54,
+	0x26, 0xf1, 0x2c, 0x13, 0xb1, 0x55, 0x4b, 0xbd, 0xbb, 0xbf, 0x28, 0x9c, 0x72, 0x9f, 0x5b, 0x6f,
+	0x94, 0x87, 0x85, 0xdf, 0x15, 0xa0, 0xb9, 0xee, 0xfd, 0x4f, 0x07, 0xf7, 0xd6, 0x26, 0xd7, 0xfd,
+	0xc3, 0x83, 0xba, 0x49, 0xf2, 0xc2, 0xfe, 0x22, 0xb2, 0x0e, 0x94, 0x4f, 0x48, 0x65, 0x6c, 0xa9,
+	0x82, 0xd6, 0xd2, 0xda, 0x44, 0xd8, 0x01, 0xb0, 0x35, 0xe2, 0x53, 0xa8, 0x9d, 0xc7, 0x3a, 0xb9,
+	0x22, 0xf0, 0x8e, 0x73, 0x2c, 0xff, 0xa4, 0xac, 0x44, 0x1f, 0x80, 0x77, 0x86, 0x9a, 0x35, 0xad,
+	0x69, 0xf1, 0x7b, 0xd1, 0x5a, 0xe7, 0x9f, 0x75, 0xa1, 0x6c, 0x87, 0xf3, 0x1d, 0xc1, 0xfb, 0x1b,
+	0x17, 0xf1, 0xd4, 0xfc, 0xca, 0xb2, 0x03, 0x28, 0x99, 0x1f, 0x88, 0xbc, 0x8c, 0x95, 0xff, 0x89,
+	0x56, 0x7d, 0x91, 0x41, 0xb1, 0x23, 0x80, 0xc5, 0xdc, 0xcc, 0xe3, 0x57, 0x26, 0x69, 0x6b, 0xc7,
+	0x1a, 0xdd, 0x84, 0x7c, 0x02, 0x6c, 0x65, 0x12, 0xda, 0x87, 0xb3, 0xbf, 0x7c, 0xde, 0xc5, 0x8c,
+	0xdc, 0x5a, 0xe0, 0x43, 0xa8, 0x44, 0xa8, 0xb4, 0x90, 0x7f, 0xe7, 0x54, 0x5f, 0xc0, 0xdb, 0x1b,
+	0x23, 0x93, 0xb5, 0xec, 0xf6, 0xbb, 0x66, 0xe9, 0xc6, 0x5d, 0x3e, 0x6e, 0xfe, 0x7a, 0xdb, 0x2e,
+	0xfc, 0x76, 0xdb, 0x2e, 0xbc, 0xb9, 0x6d, 0x17, 0x7e, 0xf8, 0xbd, 0xfd, 0xd6, 0x65, 0x99, 0x72,
+	0x3c, 0xfc, 0x73, 0x00, 0xf8, 0xb4, 0x1f, 0x09, 0x33, 0x0c, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -953,10 +1325,19 @@ const _ = grpc.SupportPackageIsVersion4
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type UserServiceClient interface {
 	Create(ctx context.Context, in *User, opts ...grpc.CallOption) (*User, error)
+	// Update writes the non-empty fields of the user and is_active, which is
+	// always written so users can be deactivated, and returns it as stored
 	Update(ctx context.Context, in *User, opts ...grpc.CallOption) (*User, error)
+	// PatchUser writes the fields named by update_mask, so fields can be cleared,
+	// and returns the user as stored
+	PatchUser(ctx context.Context, in *UpdateUserReq, opts ...grpc.CallOption) (*User, error)
 	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*UserModel, error)
 	Delete(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	List(ctx context.Context, in *GetListFilter, opts ...grpc.CallOption) (*Users, error)
+	CheckField(ctx context.Context, in *CheckFieldReq, opts ...grpc.CallOption) (*Status, error)
+	UpdateRefreshToken(ctx context.Context, in *UpdateRefreshReq, opts ...grpc.CallOption) (*empty.Empty, error)
+	Restore(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	VerifyCredentials(ctx context.Context, in *VerifyCredentialsReq, opts ...grpc.CallOption) (*UserModel, error)
 }
 
 type userServiceClient struct {
@@ -985,6 +1366,15 @@ func (c *userServiceClient) Update(ctx context.Context, in *User, opts ...grpc.C
 	return out, nil
 }
 
+func (c *userServiceClient) PatchUser(ctx context.Context, in *UpdateUserReq, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, "/user.UserService/PatchUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*UserModel, error) {
 	out := new(UserModel)
 	err := c.cc.Invoke(ctx, "/user.UserService/Get", in, out, opts...)
@@ -1012,17 +1402,62 @@ func (c *userServiceClient) List(ctx context.Context, in *GetListFilter, opts ..
 	return out, nil
 }
 
-// UserServiceServer is the server API for UserService service.
-type UserServiceServer interface {
-	Create(context.Context, *User) (*User, error)
-	Update(context.Context, *User) (*User, error)
-	Get(context.Context, *GetRequest) (*UserModel, error)
-	Delete(context.Context, *GetRequest) (*empty.Empty, error)
-	List(context.Context, *GetListFilter) (*Users, error)
-}
-
-// UnimplementedUserServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedUserServiceServer struct {
+func (c *userServiceClient) CheckField(ctx context.Context, in *CheckFieldReq, opts ...grpc.CallOption) (*Status, error) {
+	out := new(Status)
+	err := c.cc.Invoke(ctx, "/user.UserService/CheckField", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UpdateRefreshToken(ctx context.Context, in *UpdateRefreshReq, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/user.UserService/UpdateRefreshToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) Restore(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/user.UserService/Restore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) VerifyCredentials(ctx context.Context, in *VerifyCredentialsReq, opts ...grpc.CallOption) (*UserModel, error) {
+	out := new(UserModel)
+	err := c.cc.Invoke(ctx, "/user.UserService/VerifyCredentials", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UserServiceServer is the server API for UserService service.
+type UserServiceServer interface {
+	Create(context.Context, *User) (*User, error)
+	// Update writes the non-empty fields of the user and is_active, which is
+	// always written so users can be deactivated, and returns it as stored
+	Update(context.Context, *User) (*User, error)
+	// PatchUser writes the fields named by update_mask, so fields can be cleared,
+	// and returns the user as stored
+	PatchUser(context.Context, *UpdateUserReq) (*User, error)
+	Get(context.Context, *GetRequest) (*UserModel, error)
+	Delete(context.Context, *GetRequest) (*empty.Empty, error)
+	List(context.Context, *GetListFilter) (*Users, error)
+	CheckField(context.Context, *CheckFieldReq) (*Status, error)
+	UpdateRefreshToken(context.Context, *UpdateRefreshReq) (*empty.Empty, error)
+	Restore(context.Context, *GetRequest) (*empty.Empty, error)
+	VerifyCredentials(context.Context, *VerifyCredentialsReq) (*UserModel, error)
+}
+
+// UnimplementedUserServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedUserServiceServer struct {
 }
 
 func (*UnimplementedUserServiceServer) Create(ctx context.Context, req *User) (*User, error) {
@@ -1031,6 +1466,9 @@ func (*UnimplementedUserServiceServer) Create(ctx context.Context, req *User) (*
 func (*UnimplementedUserServiceServer) Update(ctx context.Context, req *User) (*User, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
 }
+func (*UnimplementedUserServiceServer) PatchUser(ctx context.Context, req *UpdateUserReq) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatchUser not implemented")
+}
 func (*UnimplementedUserServiceServer) Get(ctx context.Context, req *GetRequest) (*UserModel, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
 }
@@ -1040,6 +1478,18 @@ func (*UnimplementedUserServiceServer) Delete(ctx context.Context, req *GetReque
 func (*UnimplementedUserServiceServer) List(ctx context.Context, req *GetListFilter) (*Users, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
 }
+func (*UnimplementedUserServiceServer) CheckField(ctx context.Context, req *CheckFieldReq) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckField not implemented")
+}
+func (*UnimplementedUserServiceServer) UpdateRefreshToken(ctx context.Context, req *UpdateRefreshReq) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRefreshToken not implemented")
+}
+func (*UnimplementedUserServiceServer) Restore(ctx context.Context, req *GetRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (*UnimplementedUserServiceServer) VerifyCredentials(ctx context.Context, req *VerifyCredentialsReq) (*UserModel, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyCredentials not implemented")
+}
 
 func RegisterUserServiceServer(s *grpc.Server, srv UserServiceServer) {
 	s.RegisterService(&_UserService_serviceDesc, srv)
@@ -1081,6 +1531,24 @@ func _UserService_Update_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_PatchUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).PatchUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/PatchUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).PatchUser(ctx, req.(*UpdateUserReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetRequest)
 	if err := dec(in); err != nil {
@@ -1135,6 +1603,78 @@ func _UserService_List_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_CheckField_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckFieldReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CheckField(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/CheckField",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CheckField(ctx, req.(*CheckFieldReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UpdateRefreshToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRefreshReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateRefreshToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/UpdateRefreshToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateRefreshToken(ctx, req.(*UpdateRefreshReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/Restore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).Restore(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_VerifyCredentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyCredentialsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).VerifyCredentials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.UserService/VerifyCredentials",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).VerifyCredentials(ctx, req.(*VerifyCredentialsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _UserService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "user.UserService",
 	HandlerType: (*UserServiceServer)(nil),
@@ -1147,6 +1687,10 @@ var _UserService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Update",
 			Handler:    _UserService_Update_Handler,
 		},
+		{
+			MethodName: "PatchUser",
+			Handler:    _UserService_PatchUser_Handler,
+		},
 		{
 			MethodName: "Get",
 			Handler:    _UserService_Get_Handler,
@@ -1159,6 +1703,22 @@ var _UserService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "List",
 			Handler:    _UserService_List_Handler,
 		},
+		{
+			MethodName: "CheckField",
+			Handler:    _UserService_CheckField_Handler,
+		},
+		{
+			MethodName: "UpdateRefreshToken",
+			Handler:    _UserService_UpdateRefreshToken_Handler,
+		},
+		{
+			MethodName: "Restore",
+			Handler:    _UserService_Restore_Handler,
+		},
+		{
+			MethodName: "VerifyCredentials",
+			Handler:    _UserService_VerifyCredentials_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "user_service/user.proto",
@@ -1188,6 +1748,13 @@ func (m *User) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Etag) > 0 {
+		i -= len(m.Etag)
+		copy(dAtA[i:], m.Etag)
+		i = encodeVarintUser(dAtA, i, uint64(len(m.Etag)))
+		i--
+		dAtA[i] = 0x6a
+	}
 	if len(m.RefreshToken) > 0 {
 		i -= len(m.RefreshToken)
 		copy(dAtA[i:], m.RefreshToken)
@@ -1278,6 +1845,57 @@ func (m *User) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *UpdateUserReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateUserReq) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UpdateUserReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.UpdateMask != nil {
+		{
+			size, err := m.UpdateMask.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintUser(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.User != nil {
+		{
+			size, err := m.User.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintUser(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *GetRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1302,6 +1920,13 @@ func (m *GetRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Etag) > 0 {
+		i -= len(m.Etag)
+		copy(dAtA[i:], m.Etag)
+		i = encodeVarintUser(dAtA, i, uint64(len(m.Etag)))
+		i--
+		dAtA[i] = 0x22
+	}
 	if len(m.Username) > 0 {
 		i -= len(m.Username)
 		copy(dAtA[i:], m.Username)
@@ -1350,15 +1975,55 @@ func (m *GetListFilter) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.IsActive {
+	if len(m.PageToken) > 0 {
+		i -= len(m.PageToken)
+		copy(dAtA[i:], m.PageToken)
+		i = encodeVarintUser(dAtA, i, uint64(len(m.PageToken)))
 		i--
-		if m.IsActive {
+		dAtA[i] = 0x52
+	}
+	if len(m.Search) > 0 {
+		i -= len(m.Search)
+		copy(dAtA[i:], m.Search)
+		i = encodeVarintUser(dAtA, i, uint64(len(m.Search)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if len(m.CreatedTo) > 0 {
+		i -= len(m.CreatedTo)
+		copy(dAtA[i:], m.CreatedTo)
+		i = encodeVarintUser(dAtA, i, uint64(len(m.CreatedTo)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if len(m.CreatedFrom) > 0 {
+		i -= len(m.CreatedFrom)
+		copy(dAtA[i:], m.CreatedFrom)
+		i = encodeVarintUser(dAtA, i, uint64(len(m.CreatedFrom)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.IsActive != nil {
+		{
+			size, err := m.IsActive.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintUser(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.IncludeDeleted {
+		i--
+		if m.IncludeDeleted {
 			dAtA[i] = 1
 		} else {
 			dAtA[i] = 0
 		}
 		i--
-		dAtA[i] = 0x20
+		dAtA[i] = 0x28
 	}
 	if len(m.OrderBy) > 0 {
 		i -= len(m.OrderBy)
@@ -1499,6 +2164,47 @@ func (m *UpdateRefreshReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *VerifyCredentialsReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *VerifyCredentialsReq) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VerifyCredentialsReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Password) > 0 {
+		i -= len(m.Password)
+		copy(dAtA[i:], m.Password)
+		i = encodeVarintUser(dAtA, i, uint64(len(m.Password)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Login) > 0 {
+		i -= len(m.Login)
+		copy(dAtA[i:], m.Login)
+		i = encodeVarintUser(dAtA, i, uint64(len(m.Login)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Comment) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1709,6 +2415,20 @@ func (m *UserModel) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Etag) > 0 {
+		i -= len(m.Etag)
+		copy(dAtA[i:], m.Etag)
+		i = encodeVarintUser(dAtA, i, uint64(len(m.Etag)))
+		i--
+		dAtA[i] = 0x7a
+	}
+	if len(m.DeletedAt) > 0 {
+		i -= len(m.DeletedAt)
+		copy(dAtA[i:], m.DeletedAt)
+		i = encodeVarintUser(dAtA, i, uint64(len(m.DeletedAt)))
+		i--
+		dAtA[i] = 0x72
+	}
 	if len(m.Posts) > 0 {
 		for iNdEx := len(m.Posts) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -1837,6 +2557,13 @@ func (m *Users) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.NextPageToken) > 0 {
+		i -= len(m.NextPageToken)
+		copy(dAtA[i:], m.NextPageToken)
+		i = encodeVarintUser(dAtA, i, uint64(len(m.NextPageToken)))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if len(m.Users) > 0 {
 		for iNdEx := len(m.Users) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -1859,21 +2586,137 @@ func (m *Users) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintUser(dAtA []byte, offset int, v uint64) int {
-	offset -= sovUser(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *UserEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *User) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+
+func (m *UserEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UserEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Payload != nil {
+		{
+			size, err := m.Payload.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintUser(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.OccurredAt) > 0 {
+		i -= len(m.OccurredAt)
+		copy(dAtA[i:], m.OccurredAt)
+		i = encodeVarintUser(dAtA, i, uint64(len(m.OccurredAt)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Version != 0 {
+		i = encodeVarintUser(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.EventType) > 0 {
+		i -= len(m.EventType)
+		copy(dAtA[i:], m.EventType)
+		i = encodeVarintUser(dAtA, i, uint64(len(m.EventType)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *UserEventPayload) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UserEventPayload) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UserEventPayload) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.UserId) > 0 {
+		i -= len(m.UserId)
+		copy(dAtA[i:], m.UserId)
+		i = encodeVarintUser(dAtA, i, uint64(len(m.UserId)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.UpdateMask != nil {
+		{
+			size, err := m.UpdateMask.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintUser(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.User != nil {
+		{
+			size, err := m.User.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintUser(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintUser(dAtA []byte, offset int, v uint64) int {
+	offset -= sovUser(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *User) Size() (n int) {
+	if m == nil {
+		return 0
+	}
 	var l int
 	_ = l
 	l = len(m.Id)
@@ -1923,6 +2766,30 @@ func (m *User) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovUser(uint64(l))
 	}
+	l = len(m.Etag)
+	if l > 0 {
+		n += 1 + l + sovUser(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UpdateUserReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.User != nil {
+		l = m.User.Size()
+		n += 1 + l + sovUser(uint64(l))
+	}
+	if m.UpdateMask != nil {
+		l = m.UpdateMask.Size()
+		n += 1 + l + sovUser(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1947,6 +2814,10 @@ func (m *GetRequest) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovUser(uint64(l))
 	}
+	l = len(m.Etag)
+	if l > 0 {
+		n += 1 + l + sovUser(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1969,9 +2840,29 @@ func (m *GetListFilter) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovUser(uint64(l))
 	}
-	if m.IsActive {
+	if m.IncludeDeleted {
 		n += 2
 	}
+	if m.IsActive != nil {
+		l = m.IsActive.Size()
+		n += 1 + l + sovUser(uint64(l))
+	}
+	l = len(m.CreatedFrom)
+	if l > 0 {
+		n += 1 + l + sovUser(uint64(l))
+	}
+	l = len(m.CreatedTo)
+	if l > 0 {
+		n += 1 + l + sovUser(uint64(l))
+	}
+	l = len(m.Search)
+	if l > 0 {
+		n += 1 + l + sovUser(uint64(l))
+	}
+	l = len(m.PageToken)
+	if l > 0 {
+		n += 1 + l + sovUser(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -2033,6 +2924,26 @@ func (m *UpdateRefreshReq) Size() (n int) {
 	return n
 }
 
+func (m *VerifyCredentialsReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Login)
+	if l > 0 {
+		n += 1 + l + sovUser(uint64(l))
+	}
+	l = len(m.Password)
+	if l > 0 {
+		n += 1 + l + sovUser(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func (m *Comment) Size() (n int) {
 	if m == nil {
 		return 0
@@ -2187,6 +3098,14 @@ func (m *UserModel) Size() (n int) {
 			n += 1 + l + sovUser(uint64(l))
 		}
 	}
+	l = len(m.DeletedAt)
+	if l > 0 {
+		n += 1 + l + sovUser(uint64(l))
+	}
+	l = len(m.Etag)
+	if l > 0 {
+		n += 1 + l + sovUser(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -2208,6 +3127,61 @@ func (m *Users) Size() (n int) {
 			n += 1 + l + sovUser(uint64(l))
 		}
 	}
+	l = len(m.NextPageToken)
+	if l > 0 {
+		n += 1 + l + sovUser(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UserEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.EventType)
+	if l > 0 {
+		n += 1 + l + sovUser(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovUser(uint64(m.Version))
+	}
+	l = len(m.OccurredAt)
+	if l > 0 {
+		n += 1 + l + sovUser(uint64(l))
+	}
+	if m.Payload != nil {
+		l = m.Payload.Size()
+		n += 1 + l + sovUser(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UserEventPayload) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.User != nil {
+		l = m.User.Size()
+		n += 1 + l + sovUser(uint64(l))
+	}
+	if m.UpdateMask != nil {
+		l = m.UpdateMask.Size()
+		n += 1 + l + sovUser(uint64(l))
+	}
+	l = len(m.UserId)
+	if l > 0 {
+		n += 1 + l + sovUser(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -2621,6 +3595,38 @@ func (m *User) Unmarshal(dAtA []byte) error {
 			}
 			m.RefreshToken = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Etag", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUser
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUser
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Etag = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipUser(dAtA[iNdEx:])
@@ -2643,7 +3649,7 @@ func (m *User) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetRequest) Unmarshal(dAtA []byte) error {
+func (m *UpdateUserReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2666,17 +3672,17 @@ func (m *GetRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: UpdateUserReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UpdateUserReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field User", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowUser
@@ -2686,29 +3692,33 @@ func (m *GetRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthUser
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthUser
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UserId = string(dAtA[iNdEx:postIndex])
+			if m.User == nil {
+				m.User = &User{}
+			}
+			if err := m.User.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Email", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdateMask", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowUser
@@ -2718,55 +3728,27 @@ func (m *GetRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthUser
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthUser
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Email = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Username", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowUser
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthUser
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthUser
+			if m.UpdateMask == nil {
+				m.UpdateMask = &types.FieldMask{}
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			if err := m.UpdateMask.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			m.Username = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2790,7 +3772,7 @@ func (m *GetRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetListFilter) Unmarshal(dAtA []byte) error {
+func (m *GetRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2813,53 +3795,15 @@ func (m *GetListFilter) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetListFilter: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetListFilter: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Page", wireType)
-			}
-			m.Page = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowUser
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Page |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
-			}
-			m.Limit = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowUser
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Limit |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OrderBy", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2887,13 +3831,13 @@ func (m *GetListFilter) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.OrderBy = string(dAtA[iNdEx:postIndex])
+			m.UserId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IsActive", wireType)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Email", wireType)
 			}
-			var v int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowUser
@@ -2903,66 +3847,27 @@ func (m *GetListFilter) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.IsActive = bool(v != 0)
-		default:
-			iNdEx = preIndex
-			skippy, err := skipUser(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthUser
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *CheckFieldReq) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowUser
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUser
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: CheckFieldReq: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CheckFieldReq: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.Email = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Username", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2990,11 +3895,11 @@ func (m *CheckFieldReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Field = string(dAtA[iNdEx:postIndex])
+			m.Username = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Etag", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3022,7 +3927,7 @@ func (m *CheckFieldReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = string(dAtA[iNdEx:postIndex])
+			m.Etag = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3046,7 +3951,7 @@ func (m *CheckFieldReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Status) Unmarshal(dAtA []byte) error {
+func (m *GetListFilter) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3069,17 +3974,17 @@ func (m *Status) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Status: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetListFilter: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Status: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetListFilter: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Page", wireType)
 			}
-			var v int
+			m.Page = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowUser
@@ -3089,66 +3994,33 @@ func (m *Status) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.Page |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Status = bool(v != 0)
-		default:
-			iNdEx = preIndex
-			skippy, err := skipUser(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthUser
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *UpdateRefreshReq) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowUser
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Limit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Limit |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: UpdateRefreshReq: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UpdateRefreshReq: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderBy", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3176,13 +4048,13 @@ func (m *UpdateRefreshReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UserId = string(dAtA[iNdEx:postIndex])
+			m.OrderBy = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RefreshToken", wireType)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IncludeDeleted", wireType)
 			}
-			var stringLen uint64
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowUser
@@ -3192,23 +4064,870 @@ func (m *UpdateRefreshReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthUser
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthUser
+			m.IncludeDeleted = bool(v != 0)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsActive", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthUser
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthUser
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.IsActive == nil {
+				m.IsActive = &types.BoolValue{}
+			}
+			if err := m.IsActive.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedFrom", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUser
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUser
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CreatedFrom = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedTo", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUser
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUser
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CreatedTo = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Search", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUser
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUser
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Search = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PageToken", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUser
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUser
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PageToken = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipUser(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthUser
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CheckFieldReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowUser
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CheckFieldReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CheckFieldReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUser
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUser
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Field = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUser
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUser
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipUser(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthUser
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Status) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowUser
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Status: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Status: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Status = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipUser(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthUser
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpdateRefreshReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowUser
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdateRefreshReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdateRefreshReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUser
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUser
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UserId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RefreshToken", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUser
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUser
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RefreshToken = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipUser(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthUser
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *VerifyCredentialsReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowUser
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VerifyCredentialsReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VerifyCredentialsReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Login", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUser
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUser
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Login = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Password", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUser
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUser
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Password = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipUser(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthUser
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Comment) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowUser
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Comment: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Comment: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUser
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUser
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PostId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUser
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUser
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PostId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUser
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUser
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RefreshToken = string(dAtA[iNdEx:postIndex])
+			m.UserId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Content", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUser
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUser
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Content = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUser
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUser
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CreatedAt = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedAt", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUser
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUser
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UpdatedAt = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthUser
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthUser
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Owner == nil {
+				m.Owner = &User{}
+			}
+			if err := m.Owner.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3232,7 +4951,7 @@ func (m *UpdateRefreshReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Comment) Unmarshal(dAtA []byte) error {
+func (m *Post) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3255,10 +4974,10 @@ func (m *Comment) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Comment: wiretype end group for non-group")
+			return fmt.Errorf("proto: Post: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Comment: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Post: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -3295,9 +5014,124 @@ func (m *Comment) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PostId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUser
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUser
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UserId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Content", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUser
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUser
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Content = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Title", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUser
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUser
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Title = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Likes", wireType)
+			}
+			m.Likes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Likes |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			var stringLen uint64
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dislikes", wireType)
+			}
+			m.Dislikes = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowUser
@@ -3307,29 +5141,16 @@ func (m *Comment) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Dislikes |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthUser
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthUser
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.PostId = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Views", wireType)
 			}
-			var stringLen uint64
+			m.Views = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowUser
@@ -3339,27 +5160,14 @@ func (m *Comment) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Views |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthUser
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthUser
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.UserId = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Content", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Category", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3387,9 +5195,9 @@ func (m *Comment) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Content = string(dAtA[iNdEx:postIndex])
+			m.Category = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 5:
+		case 9:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
 			}
@@ -3421,7 +5229,7 @@ func (m *Comment) Unmarshal(dAtA []byte) error {
 			}
 			m.CreatedAt = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 6:
+		case 10:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedAt", wireType)
 			}
@@ -3453,9 +5261,9 @@ func (m *Comment) Unmarshal(dAtA []byte) error {
 			}
 			m.UpdatedAt = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 7:
+		case 11:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Comments", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3482,10 +5290,8 @@ func (m *Comment) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Owner == nil {
-				m.Owner = &User{}
-			}
-			if err := m.Owner.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Comments = append(m.Comments, &Comment{})
+			if err := m.Comments[len(m.Comments)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -3511,7 +5317,7 @@ func (m *Comment) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Post) Unmarshal(dAtA []byte) error {
+func (m *UserModel) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3534,10 +5340,10 @@ func (m *Post) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Post: wiretype end group for non-group")
+			return fmt.Errorf("proto: UserModel: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Post: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UserModel: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -3574,7 +5380,7 @@ func (m *Post) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Username", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3602,11 +5408,11 @@ func (m *Post) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UserId = string(dAtA[iNdEx:postIndex])
+			m.Username = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Content", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Email", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3634,11 +5440,11 @@ func (m *Post) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Content = string(dAtA[iNdEx:postIndex])
+			m.Email = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Title", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Password", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3666,68 +5472,11 @@ func (m *Post) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Title = string(dAtA[iNdEx:postIndex])
+			m.Password = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Likes", wireType)
-			}
-			m.Likes = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowUser
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Likes |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Dislikes", wireType)
-			}
-			m.Dislikes = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowUser
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Dislikes |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 7:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Views", wireType)
-			}
-			m.Views = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowUser
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Views |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Category", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field FirstName", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3755,11 +5504,11 @@ func (m *Post) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Category = string(dAtA[iNdEx:postIndex])
+			m.FirstName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 9:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LastName", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3787,11 +5536,11 @@ func (m *Post) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.CreatedAt = string(dAtA[iNdEx:postIndex])
+			m.LastName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 10:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedAt", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Bio", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3819,13 +5568,13 @@ func (m *Post) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UpdatedAt = string(dAtA[iNdEx:postIndex])
+			m.Bio = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 11:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Comments", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Website", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowUser
@@ -3835,80 +5584,27 @@ func (m *Post) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthUser
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthUser
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Comments = append(m.Comments, &Comment{})
-			if err := m.Comments[len(m.Comments)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Website = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipUser(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthUser
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *UserModel) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowUser
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: UserModel: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UserModel: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 9:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3936,11 +5632,11 @@ func (m *UserModel) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Id = string(dAtA[iNdEx:postIndex])
+			m.CreatedAt = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Username", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedAt", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3968,11 +5664,31 @@ func (m *UserModel) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Username = string(dAtA[iNdEx:postIndex])
+			m.UpdatedAt = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsActive", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsActive = bool(v != 0)
+		case 12:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Email", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RefreshToken", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4000,13 +5716,13 @@ func (m *UserModel) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Email = string(dAtA[iNdEx:postIndex])
+			m.RefreshToken = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
+		case 13:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Password", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Posts", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowUser
@@ -4016,27 +5732,29 @@ func (m *UserModel) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthUser
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthUser
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Password = string(dAtA[iNdEx:postIndex])
+			m.Posts = append(m.Posts, &Post{})
+			if err := m.Posts[len(m.Posts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 5:
+		case 14:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FirstName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DeletedAt", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4064,11 +5782,11 @@ func (m *UserModel) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.FirstName = string(dAtA[iNdEx:postIndex])
+			m.DeletedAt = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 6:
+		case 15:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LastName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Etag", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4096,13 +5814,64 @@ func (m *UserModel) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.LastName = string(dAtA[iNdEx:postIndex])
+			m.Etag = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Bio", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipUser(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			var stringLen uint64
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthUser
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Users) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowUser
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Users: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Users: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			m.Count = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowUser
@@ -4112,29 +5881,16 @@ func (m *UserModel) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Count |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthUser
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthUser
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Bio = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 8:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Website", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Users", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowUser
@@ -4144,27 +5900,29 @@ func (m *UserModel) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthUser
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthUser
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Website = string(dAtA[iNdEx:postIndex])
+			m.Users = append(m.Users, &UserModel{})
+			if err := m.Users[len(m.Users)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 9:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NextPageToken", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4192,11 +5950,62 @@ func (m *UserModel) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.CreatedAt = string(dAtA[iNdEx:postIndex])
+			m.NextPageToken = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 10:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipUser(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthUser
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UserEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowUser
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UserEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UserEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedAt", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EventType", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4224,13 +6033,13 @@ func (m *UserModel) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UpdatedAt = string(dAtA[iNdEx:postIndex])
+			m.EventType = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 11:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IsActive", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
-			var v int
+			m.Version = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowUser
@@ -4240,15 +6049,14 @@ func (m *UserModel) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.Version |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.IsActive = bool(v != 0)
-		case 12:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RefreshToken", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OccurredAt", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4276,11 +6084,11 @@ func (m *UserModel) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RefreshToken = string(dAtA[iNdEx:postIndex])
+			m.OccurredAt = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 13:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Posts", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4307,8 +6115,10 @@ func (m *UserModel) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Posts = append(m.Posts, &Post{})
-			if err := m.Posts[len(m.Posts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Payload == nil {
+				m.Payload = &UserEventPayload{}
+			}
+			if err := m.Payload.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -4334,7 +6144,7 @@ func (m *UserModel) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Users) Unmarshal(dAtA []byte) error {
+func (m *UserEventPayload) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4357,17 +6167,17 @@ func (m *Users) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Users: wiretype end group for non-group")
+			return fmt.Errorf("proto: UserEventPayload: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Users: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UserEventPayload: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field User", wireType)
 			}
-			m.Count = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowUser
@@ -4377,14 +6187,31 @@ func (m *Users) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Count |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthUser
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthUser
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.User == nil {
+				m.User = &User{}
+			}
+			if err := m.User.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Users", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdateMask", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4411,11 +6238,45 @@ func (m *Users) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Users = append(m.Users, &UserModel{})
-			if err := m.Users[len(m.Users)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.UpdateMask == nil {
+				m.UpdateMask = &types.FieldMask{}
+			}
+			if err := m.UpdateMask.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUser
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthUser
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthUser
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UserId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipUser(dAtA[iNdEx:])
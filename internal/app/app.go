@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	pb "fourth-exam/user-service-evrone/genproto/user_service"
 	"fourth-exam/user-service-evrone/internal/delivery/grpc/server"
@@ -10,10 +11,13 @@ import (
 	repo "fourth-exam/user-service-evrone/internal/infrastructure/repository/postgresql"
 	"fourth-exam/user-service-evrone/internal/pkg/config"
 	"fourth-exam/user-service-evrone/internal/pkg/logger"
+	"fourth-exam/user-service-evrone/internal/pkg/metrics"
 	"fourth-exam/user-service-evrone/internal/pkg/otlp"
+	"fourth-exam/user-service-evrone/internal/pkg/password"
 	"fourth-exam/user-service-evrone/internal/pkg/postgres"
 	"fourth-exam/user-service-evrone/internal/usecase"
 	"fourth-exam/user-service-evrone/internal/usecase/event"
+	"net/http"
 	"time"
 
 	"go.uber.org/zap"
@@ -26,11 +30,20 @@ type App struct {
 	DB             *postgres.PostgresDB
 	ServiceClients grpc_service_clients.ServiceClients
 	GrpcServer     *grpc.Server
+	MetricsServer  *http.Server
 	ShutdownOTLP   func() error
 	BrokerConsumer event.BrokerConsumer
+	BrokerProducer event.BrokerProducer
+	// ShutdownTimeout bounds waiting for running handlers of BrokerConsumer on Stop
+	ShutdownTimeout time.Duration
 }
 
 func NewApp(cfg *config.Config) (*App, error) {
+	shutdownTimeout, err := consumerShutdownTimeout(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	logger, err := logger.New(cfg.LogLevel, cfg.Environment, cfg.APP+".log")
 	if err != nil {
 		return nil, err
@@ -51,16 +64,30 @@ func NewApp(cfg *config.Config) (*App, error) {
 	if err != nil {
 		return nil, err
 	}
-	brokerConsumer := kafka.NewConsumer(logger)
+	producerConfig, err := kafka.NewProducerConfig(cfg, cfg.Kafka.Topic.UserEvents)
+	if err != nil {
+		return nil, err
+	}
+	brokerProducer, err := newBrokerProducer(cfg, logger, producerConfig)
+	if err != nil {
+		return nil, err
+	}
+	brokerConsumer, err := newBrokerConsumer(cfg, logger, brokerProducer)
+	if err != nil {
+		return nil, err
+	}
 
 	return &App{
-		Config:         cfg,
-		Logger:         logger,
-		DB:             db,
-		GrpcServer:     grpcServer,
-		ServiceClients: clients,
-		BrokerConsumer: brokerConsumer,
-		ShutdownOTLP:   shutdownOTLP,
+		Config:          cfg,
+		Logger:          logger,
+		DB:              db,
+		GrpcServer:      grpcServer,
+		MetricsServer:   metrics.NewServer(cfg.Metrics.Address),
+		ServiceClients:  clients,
+		BrokerConsumer:  brokerConsumer,
+		BrokerProducer:  brokerProducer,
+		ShutdownOTLP:    shutdownOTLP,
+		ShutdownTimeout: shutdownTimeout,
 	}, nil
 }
 
@@ -80,13 +107,20 @@ func (a *App) Run() error {
 	}
 	a.ServiceClients = serviceClients
 
-	userRepo := repo.NewUsersRepo(a.DB)
+	hasher, err := password.New(a.Config)
+	if err != nil {
+		return fmt.Errorf("error during initialize password hasher: %w", err)
+	}
+	userRepo := repo.NewUsersRepo(a.DB, hasher)
+	outboxRepo := repo.NewOutboxRepo(a.DB)
 
-	userUseCase := usecase.NewUserService(contextTimeout, userRepo)
+	userUseCase := usecase.NewUserService(contextTimeout, userRepo, outboxRepo, a.DB, hasher)
 
 	pb.RegisterUserServiceServer(a.GrpcServer, services.NewRPC(a.Logger, userUseCase))
 
-	// a.BrokerConsumer.Run()
+	// a.BrokerConsumer.Run(ctx)
+
+	go serveMetrics(a.Logger, a.MetricsServer)
 
 	a.Logger.Info("gRPC Server Listening", zap.String("url", a.Config.RPCPort))
 	if err := server.Run(a.Config, a.GrpcServer); err != nil {
@@ -100,12 +134,28 @@ func (a *App) Stop() {
 	a.ServiceClients.Close()
 	// stop gRPC server
 	a.GrpcServer.Stop()
+	// stop metrics server
+	shutdownMetrics(a.Logger, a.MetricsServer)
+
+	// broker consumer connection, running handlers get the shutdown timeout to finish
+	ctx, cancel := context.WithTimeout(context.Background(), a.ShutdownTimeout)
+	defer cancel()
+	if err := a.BrokerConsumer.Close(ctx); err != nil {
+		a.Logger.Error("broker consumer close", zap.Error(err))
+	}
 
-	// database connection
+	// database connection, handlers are done with it
 	a.DB.Close()
 
-	// broker consumer connection
-	a.BrokerConsumer.Close()
+	// broker producer flushes queued messages
+	if err := a.BrokerProducer.Close(); err != nil {
+		a.Logger.Error("broker producer close", zap.Error(err))
+	}
+
+	// flush remaining spans
+	if err := a.ShutdownOTLP(); err != nil {
+		a.Logger.Error("otlp shutdown", zap.Error(err))
+	}
 
 	// zap logger sync
 	a.Logger.Sync()
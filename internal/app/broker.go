@@ -0,0 +1,86 @@
+package app
+
+import (
+	"fmt"
+	"fourth-exam/user-service-evrone/internal/infrastructure/kafka"
+	"fourth-exam/user-service-evrone/internal/infrastructure/memory"
+	"fourth-exam/user-service-evrone/internal/pkg/config"
+	"fourth-exam/user-service-evrone/internal/usecase/event"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	BrokerDriverKafka  = "kafka"
+	BrokerDriverMemory = "memory"
+)
+
+var (
+	memoryBrokerOnce sync.Once
+	memoryBroker     *memory.Broker
+	memoryBrokerErr  error
+)
+
+// sharedMemoryBroker returns the memory broker of the process, producers and
+// consumers built in one process see the same topics
+func sharedMemoryBroker(conf *config.Config) (*memory.Broker, error) {
+	memoryBrokerOnce.Do(func() {
+		partitions, err := strconv.Atoi(conf.Broker.Partitions)
+		if err != nil || partitions <= 0 {
+			memoryBrokerErr = fmt.Errorf("invalid memory broker partitions '%s'", conf.Broker.Partitions)
+			return
+		}
+		memoryBroker = memory.NewBroker(partitions)
+	})
+	return memoryBroker, memoryBrokerErr
+}
+
+// newBrokerProducer creates the producer of the configured broker driver,
+// the memory producer stores messages synchronously whatever producerConfig says
+func newBrokerProducer(conf *config.Config, logger *zap.Logger, producerConfig *kafka.ProducerConfig) (event.BrokerProducer, error) {
+	switch conf.Broker.Driver {
+	case BrokerDriverKafka:
+		return kafka.NewProducer(logger, producerConfig), nil
+	case BrokerDriverMemory:
+		broker, err := sharedMemoryBroker(conf)
+		if err != nil {
+			return nil, err
+		}
+		return memory.NewProducer(broker, producerConfig.Topic), nil
+	default:
+		return nil, fmt.Errorf("unknown broker driver '%s'", conf.Broker.Driver)
+	}
+}
+
+// newBrokerConsumer creates the consumer of the configured broker driver,
+// producer writes its dead-lettered messages
+func newBrokerConsumer(conf *config.Config, logger *zap.Logger, producer event.BrokerProducer) (event.BrokerConsumer, error) {
+	switch conf.Broker.Driver {
+	case BrokerDriverKafka:
+		return kafka.NewConsumer(logger, producer), nil
+	case BrokerDriverMemory:
+		broker, err := sharedMemoryBroker(conf)
+		if err != nil {
+			return nil, err
+		}
+		return memory.NewConsumer(logger, broker, producer), nil
+	default:
+		return nil, fmt.Errorf("unknown broker driver '%s'", conf.Broker.Driver)
+	}
+}
+
+// consumerShutdownTimeout reads how long closing consumers wait for running
+// handlers, it is read on start so a typo does not abort every handler on stop
+func consumerShutdownTimeout(conf *config.Config) (time.Duration, error) {
+	timeout, err := time.ParseDuration(conf.Kafka.Consumer.ShutdownTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("error during parse duration for kafka consumer shutdown timeout : %w", err)
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("invalid kafka consumer shutdown timeout '%s'", conf.Kafka.Consumer.ShutdownTimeout)
+	}
+	return timeout, nil
+}
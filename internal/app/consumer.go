@@ -1,14 +1,20 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"fourth-exam/user-service-evrone/internal/delivery/kafka/handlers"
 	"fourth-exam/user-service-evrone/internal/infrastructure/kafka"
 	"fourth-exam/user-service-evrone/internal/infrastructure/repository/postgresql"
+	"fourth-exam/user-service-evrone/internal/pkg/codec"
 	"fourth-exam/user-service-evrone/internal/pkg/config"
+	"fourth-exam/user-service-evrone/internal/pkg/metrics"
+	"fourth-exam/user-service-evrone/internal/pkg/otlp"
+	"fourth-exam/user-service-evrone/internal/pkg/password"
 	"fourth-exam/user-service-evrone/internal/pkg/postgres"
 	"fourth-exam/user-service-evrone/internal/usecase"
 	"fourth-exam/user-service-evrone/internal/usecase/event"
+	"net/http"
 	"time"
 
 	logpkg "fourth-exam/user-service-evrone/internal/pkg/logger"
@@ -21,44 +27,129 @@ type UserConsumer struct {
 	Logger         *zap.Logger
 	DB             *postgres.PostgresDB
 	BrokerConsumer event.BrokerConsumer
+	BrokerProducer event.BrokerProducer
+	MetricsServer  *http.Server
+	ShutdownOTLP   func() error
+	// ShutdownTimeout bounds waiting for running handlers on Close
+	ShutdownTimeout time.Duration
 }
 
 func NewUserConsumer(conf *config.Config) (*UserConsumer, error) {
+	shutdownTimeout, err := consumerShutdownTimeout(conf)
+	if err != nil {
+		return nil, err
+	}
+
 	logger, err := logpkg.New(conf.LogLevel, conf.Environment, conf.APP+"_cli"+".lo")
 	if err != nil {
 		return nil, err
 	}
 
-	consumer := kafka.NewConsumer(logger)
+	shutdownOTLP, err := otlp.InitOTLPProvider(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	// dead-lettered messages are committed once produced, so they have to be acknowledged first
+	producerConfig, err := kafka.NewProducerConfig(conf, conf.Kafka.Topic.UserTopicDeadLetter)
+	if err != nil {
+		return nil, err
+	}
+	producerConfig.Async = false
+	producer, err := newBrokerProducer(conf, logger, producerConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	consumer, err := newBrokerConsumer(conf, logger, producer)
+	if err != nil {
+		return nil, err
+	}
 
 	db, err := postgres.New(conf)
 	if err != nil {
 		return nil, err
 	}
 
-	return &UserConsumer{Config: conf, Logger: logger, DB: db, BrokerConsumer: consumer}, nil
+	return &UserConsumer{Config: conf, Logger: logger, DB: db, BrokerConsumer: consumer, BrokerProducer: producer, MetricsServer: metrics.NewServer(conf.Metrics.Address), ShutdownOTLP: shutdownOTLP, ShutdownTimeout: shutdownTimeout}, nil
 }
 
-func (u *UserConsumer) Run() error {
+// Run runs the registered consumers of names until ctx is done or Close is
+// called, it returns when consumption stops and reports why
+func (u *UserConsumer) Run(ctx context.Context, names []string) error {
 
 	// repo init
-	userRepo := postgresql.NewUsersRepo(u.DB)
+	hasher, err := password.New(u.Config)
+	if err != nil {
+		return fmt.Errorf("error during initialize password hasher: %w", err)
+	}
+	userRepo := postgresql.NewUsersRepo(u.DB, hasher)
+	outboxRepo := postgresql.NewOutboxRepo(u.DB)
 
 	// usecase init
 	duration, err := time.ParseDuration(u.Config.Context.Timeout)
 	if err != nil {
 		return fmt.Errorf("error during parse duration for context timeout : %w", err)
 	}
-	userUseCase := usecase.NewUserService(duration, userRepo)
+	userUseCase := usecase.NewUserService(duration, userRepo, outboxRepo, u.DB, hasher)
+	inboxUseCase := usecase.NewInboxService(duration, postgresql.NewProcessedMessageRepo(u.DB), u.DB)
+
+	// event handlers
+	deps := handlers.Dependencies{
+		Config:       u.Config,
+		Logger:       u.Logger,
+		UserUsecase:  userUseCase,
+		InboxUsecase: inboxUseCase,
+		Codecs:       codec.New(),
+	}
+	registered := make(map[string]bool, len(names))
+	for _, name := range names {
+		registration, ok := handlers.Lookup(name)
+		if !ok {
+			return fmt.Errorf("no consumer with name '%s'", name)
+		}
+		// a consumer named by its alias too runs once
+		if registered[registration.Name] {
+			continue
+		}
+		registered[registration.Name] = true
+
+		consumerConfig, err := registration.ConsumerConfig(deps)
+		if err != nil {
+			return err
+		}
+		u.BrokerConsumer.RegisterConsumer(consumerConfig)
+		u.Logger.Info("consumer registered", zap.String("name", name), zap.String("topic", consumerConfig.GetTopic()), zap.String("group_id", consumerConfig.GetGroupID()))
+	}
 
-	// event handler
-	eventHandler := handlers.NewUserConsumerHandler(u.Config, u.BrokerConsumer, u.Logger, userUseCase)
+	go serveMetrics(u.Logger, u.MetricsServer)
 
-	return eventHandler.HandlerEvents()
+	return u.BrokerConsumer.Run(ctx)
 }
 
+// Close stops consuming and waits up to the shutdown timeout for running
+// handlers before releasing connections
 func (u *UserConsumer) Close() {
-	u.BrokerConsumer.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), u.ShutdownTimeout)
+	defer cancel()
+
+	if err := u.BrokerConsumer.Close(ctx); err != nil {
+		u.Logger.Error("broker consumer close", zap.Error(err))
+	}
+
+	// metrics of the drained messages were served until here
+	shutdownMetrics(u.Logger, u.MetricsServer)
+
+	if err := u.BrokerProducer.Close(); err != nil {
+		u.Logger.Error("broker producer close", zap.Error(err))
+	}
+
+	u.DB.Close()
+
+	// flush spans of handled messages
+	if err := u.ShutdownOTLP(); err != nil {
+		u.Logger.Error("otlp shutdown", zap.Error(err))
+	}
 
 	u.Logger.Sync()
 }
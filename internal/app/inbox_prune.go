@@ -0,0 +1,54 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"fourth-exam/user-service-evrone/internal/infrastructure/repository/postgresql"
+	"fourth-exam/user-service-evrone/internal/pkg/config"
+	"fourth-exam/user-service-evrone/internal/pkg/postgres"
+	"fourth-exam/user-service-evrone/internal/usecase"
+	"time"
+
+	logpkg "fourth-exam/user-service-evrone/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type InboxPrune struct {
+	Config *config.Config
+	Logger *zap.Logger
+	DB     *postgres.PostgresDB
+}
+
+func NewInboxPrune(conf *config.Config) (*InboxPrune, error) {
+	logger, err := logpkg.New(conf.LogLevel, conf.Environment, conf.APP+"_inbox_prune"+".log")
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := postgres.New(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InboxPrune{Config: conf, Logger: logger, DB: db}, nil
+}
+
+// Run forgets consumed messages processed longer than retention ago, a
+// message redelivered after that is processed again
+func (i *InboxPrune) Run(retention time.Duration) (int64, error) {
+	// usecase init
+	duration, err := time.ParseDuration(i.Config.Context.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("error during parse duration for context timeout : %w", err)
+	}
+	inboxUseCase := usecase.NewInboxService(duration, postgresql.NewProcessedMessageRepo(i.DB), i.DB)
+
+	return inboxUseCase.Prune(context.Background(), retention)
+}
+
+func (i *InboxPrune) Close() {
+	i.DB.Close()
+
+	i.Logger.Sync()
+}
@@ -0,0 +1,30 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// metricsShutdownTimeout bounds waiting for running scrapes on stop
+const metricsShutdownTimeout = 5 * time.Second
+
+// serveMetrics serves metrics until the server is shut down
+func serveMetrics(logger *zap.Logger, server *http.Server) {
+	logger.Info("Metrics Server Listening", zap.String("url", server.Addr))
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.Error("metrics server", zap.Error(err))
+	}
+}
+
+func shutdownMetrics(logger *zap.Logger, server *http.Server) {
+	ctx, cancel := context.WithTimeout(context.Background(), metricsShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		logger.Error("metrics server shutdown", zap.Error(err))
+	}
+}
@@ -0,0 +1,115 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"fourth-exam/user-service-evrone/internal/infrastructure/kafka"
+	"fourth-exam/user-service-evrone/internal/infrastructure/repository/postgresql"
+	"fourth-exam/user-service-evrone/internal/pkg/config"
+	"fourth-exam/user-service-evrone/internal/pkg/otlp"
+	"fourth-exam/user-service-evrone/internal/pkg/postgres"
+	"fourth-exam/user-service-evrone/internal/usecase"
+	"fourth-exam/user-service-evrone/internal/usecase/event"
+	"strconv"
+	"time"
+
+	logpkg "fourth-exam/user-service-evrone/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type OutboxRelay struct {
+	Config       *config.Config
+	Logger       *zap.Logger
+	DB           *postgres.PostgresDB
+	Producer     event.BrokerProducer
+	ShutdownOTLP func() error
+}
+
+func NewOutboxRelay(conf *config.Config) (*OutboxRelay, error) {
+	logger, err := logpkg.New(conf.LogLevel, conf.Environment, conf.APP+"_outbox"+".log")
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := postgres.New(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	shutdownOTLP, err := otlp.InitOTLPProvider(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	producerConfig, err := kafka.NewProducerConfig(conf, conf.Kafka.Topic.UserEvents)
+	if err != nil {
+		return nil, err
+	}
+	// events are marked as sent once produced, so they have to be acknowledged first
+	producerConfig.Async = false
+	producer, err := newBrokerProducer(conf, logger, producerConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OutboxRelay{Config: conf, Logger: logger, DB: db, Producer: producer, ShutdownOTLP: shutdownOTLP}, nil
+}
+
+// Run publishes pending user events until ctx is done. A full batch is
+// followed by the next one right away, otherwise the relay waits a poll interval
+func (o *OutboxRelay) Run(ctx context.Context) error {
+	pollInterval, err := time.ParseDuration(o.Config.Outbox.PollInterval)
+	if err != nil {
+		return fmt.Errorf("error during parse duration for outbox poll interval : %w", err)
+	}
+	batchSize, err := strconv.ParseUint(o.Config.Outbox.BatchSize, 10, 64)
+	if err != nil || batchSize == 0 {
+		return fmt.Errorf("invalid outbox batch size '%s'", o.Config.Outbox.BatchSize)
+	}
+
+	// usecase init
+	duration, err := time.ParseDuration(o.Config.Context.Timeout)
+	if err != nil {
+		return fmt.Errorf("error during parse duration for context timeout : %w", err)
+	}
+	relay := usecase.NewOutboxRelay(duration, postgresql.NewOutboxRepo(o.DB), o.DB, o.Producer)
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-timer.C:
+		}
+
+		sent, err := relay.Relay(ctx, batchSize)
+		if err != nil && ctx.Err() == nil {
+			o.Logger.Error("outbox relay failed to publish events", zap.Error(err))
+		}
+		if sent > 0 {
+			o.Logger.Debug("outbox relay published events", zap.Int("count", sent))
+		}
+
+		if uint64(sent) == batchSize {
+			timer.Reset(0)
+		} else {
+			timer.Reset(pollInterval)
+		}
+	}
+}
+
+func (o *OutboxRelay) Close() {
+	if err := o.Producer.Close(); err != nil {
+		o.Logger.Error("outbox producer close", zap.Error(err))
+	}
+
+	o.DB.Close()
+
+	if err := o.ShutdownOTLP(); err != nil {
+		o.Logger.Error("otlp shutdown", zap.Error(err))
+	}
+
+	o.Logger.Sync()
+}
@@ -0,0 +1,54 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"fourth-exam/user-service-evrone/internal/infrastructure/repository/postgresql"
+	"fourth-exam/user-service-evrone/internal/pkg/config"
+	"fourth-exam/user-service-evrone/internal/pkg/postgres"
+	"fourth-exam/user-service-evrone/internal/usecase"
+	"time"
+
+	logpkg "fourth-exam/user-service-evrone/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type OutboxPrune struct {
+	Config *config.Config
+	Logger *zap.Logger
+	DB     *postgres.PostgresDB
+}
+
+func NewOutboxPrune(conf *config.Config) (*OutboxPrune, error) {
+	logger, err := logpkg.New(conf.LogLevel, conf.Environment, conf.APP+"_outbox_prune"+".log")
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := postgres.New(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OutboxPrune{Config: conf, Logger: logger, DB: db}, nil
+}
+
+// Run deletes user events sent longer than retention ago, unsent ones stay
+func (o *OutboxPrune) Run(retention time.Duration) (int64, error) {
+	// usecase init
+	duration, err := time.ParseDuration(o.Config.Context.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("error during parse duration for context timeout : %w", err)
+	}
+	// pruning publishes nothing, so the relay needs no producer
+	outboxRelay := usecase.NewOutboxRelay(duration, postgresql.NewOutboxRepo(o.DB), o.DB, nil)
+
+	return outboxRelay.Prune(context.Background(), retention)
+}
+
+func (o *OutboxPrune) Close() {
+	o.DB.Close()
+
+	o.Logger.Sync()
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"fourth-exam/user-service-evrone/internal/infrastructure/repository/postgresql"
+	"fourth-exam/user-service-evrone/internal/pkg/config"
+	"fourth-exam/user-service-evrone/internal/pkg/password"
+	"fourth-exam/user-service-evrone/internal/pkg/postgres"
+	"fourth-exam/user-service-evrone/internal/usecase"
+	"time"
+
+	logpkg "fourth-exam/user-service-evrone/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type UserPurge struct {
+	Config *config.Config
+	Logger *zap.Logger
+	DB     *postgres.PostgresDB
+}
+
+func NewUserPurge(conf *config.Config) (*UserPurge, error) {
+	logger, err := logpkg.New(conf.LogLevel, conf.Environment, conf.APP+"_purge"+".log")
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := postgres.New(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserPurge{Config: conf, Logger: logger, DB: db}, nil
+}
+
+// Run hard deletes users soft deleted longer than retention ago
+func (u *UserPurge) Run(retention time.Duration) (int64, error) {
+	// repo init
+	hasher, err := password.New(u.Config)
+	if err != nil {
+		return 0, fmt.Errorf("error during initialize password hasher: %w", err)
+	}
+	userRepo := postgresql.NewUsersRepo(u.DB, hasher)
+	outboxRepo := postgresql.NewOutboxRepo(u.DB)
+
+	// usecase init
+	duration, err := time.ParseDuration(u.Config.Context.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("error during parse duration for context timeout : %w", err)
+	}
+	userUseCase := usecase.NewUserService(duration, userRepo, outboxRepo, u.DB, hasher)
+
+	return userUseCase.Purge(context.Background(), retention)
+}
+
+func (u *UserPurge) Close() {
+	u.DB.Close()
+
+	u.Logger.Sync()
+}
@@ -4,21 +4,23 @@ import (
 	"context"
 	"errors"
 	"fourth-exam/user-service-evrone/internal/entity"
+	"sort"
 
 	epb "google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
-var (
-	errNotFound   *entity.ErrNotFound
-	errConflict   *entity.ErrConflict
-	errValidation *entity.ErrValidation
-)
-
 func ErrorStatus(ctx context.Context, err error) *status.Status {
 	var (
 		st *status.Status
+
+		// errors.As targets are per call, concurrent requests must not share them
+		errNotFound        *entity.ErrNotFound
+		errConflict        *entity.ErrConflict
+		errValidation      *entity.ErrValidation
+		errUnauthenticated *entity.ErrUnauthenticated
+		errVersionMismatch *entity.ErrVersionMismatch
 	)
 	switch {
 	// error not found
@@ -27,6 +29,18 @@ func ErrorStatus(ctx context.Context, err error) *status.Status {
 	// error conflict
 	case errors.As(err, &errConflict):
 		st = status.New(codes.AlreadyExists, err.Error())
+		if field := errConflict.Field(); field != "" {
+			st, _ = st.WithDetails(&epb.ErrorInfo{
+				Reason:   "FIELD_ALREADY_EXISTS",
+				Metadata: map[string]string{"field": field},
+			})
+		}
+	// error version mismatch
+	case errors.As(err, &errVersionMismatch):
+		st = status.New(codes.Aborted, err.Error())
+	// error unauthenticated
+	case errors.As(err, &errUnauthenticated):
+		st = status.New(codes.Unauthenticated, err.Error())
 	// error validation errors
 	case errors.As(err, &errValidation):
 		st = status.New(codes.InvalidArgument, codes.InvalidArgument.String())
@@ -37,6 +51,9 @@ func ErrorStatus(ctx context.Context, err error) *status.Status {
 				Description: des,
 			})
 		}
+		sort.Slice(br.FieldViolations, func(i, j int) bool {
+			return br.FieldViolations[i].Field < br.FieldViolations[j].Field
+		})
 		st, _ = st.WithDetails(br)
 	// error internal
 	default:
@@ -2,11 +2,13 @@ package services
 
 import (
 	"context"
+	"errors"
 	pb "fourth-exam/user-service-evrone/genproto/user_service"
 	grpc "fourth-exam/user-service-evrone/internal/delivery"
 	"fourth-exam/user-service-evrone/internal/entity"
 	"fourth-exam/user-service-evrone/internal/pkg/otlp"
 	"fourth-exam/user-service-evrone/internal/usecase"
+	"strconv"
 	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
@@ -47,7 +49,7 @@ func (d *userRPC) Create(ctx context.Context, in *pb.User) (*pb.User, error) {
 		FirstName:    in.FirstName,
 		LastName:     in.LastName,
 		Bio:          in.Bio,
-		Website:      in.Bio,
+		Website:      in.Website,
 		RefreshToken: in.RefreshToken,
 		CreatedAt:    time.Now(),
 	})
@@ -56,6 +58,7 @@ func (d *userRPC) Create(ctx context.Context, in *pb.User) (*pb.User, error) {
 		return &pb.User{}, grpc.Error(ctx, err)
 	}
 	in.Id = id
+	in.Password = ""
 	return in, nil
 }
 
@@ -65,57 +68,117 @@ func (d *userRPC) Update(ctx context.Context, in *pb.User) (*pb.User, error) {
 
 	span.SetAttributes(attribute.KeyValue{Key: "User -> delivery -> ", Value: attribute.StringValue("Update")})
 
-	err := d.userUsecase.Update(ctx, &entity.User{
-		Id:           in.Id,
-		Email:        in.Email,
-		Password:     in.Password,
-		Username:     in.Username,
-		FirstName:    in.FirstName,
-		LastName:     in.LastName,
-		Bio:          in.Bio,
-		Website:      in.Bio,
-		RefreshToken: in.RefreshToken,
-		UpdatedAt:    time.Now(),
-	})
+	req, err := updateRequest(in)
 	if err != nil {
-		d.logger.Error("userUseCase.Update", zap.Error(err))
 		return &pb.User{}, grpc.Error(ctx, err)
 	}
 
-	return in, nil
+	// without a mask the non-empty fields are written and is_active always,
+	// as the RPC did before masks, so users can still be deactivated with it
+	return d.update(ctx, req, append(usecase.PopulatedFields(req), "is_active"))
 }
 
-func (d *userRPC) Get(ctx context.Context, in *pb.GetRequest) (*pb.UserModel, error) {
-	ctx, span := otlp.Start(ctx, serviceNameUser, spanNameUser+"Get")
+func (d *userRPC) PatchUser(ctx context.Context, in *pb.UpdateUserReq) (*pb.User, error) {
+	ctx, span := otlp.Start(ctx, serviceNameUser, spanNameUser+"PatchUser")
 	defer span.End()
 
-	span.SetAttributes(attribute.KeyValue{Key: "User -> delivery -> ", Value: attribute.StringValue("Get")})
+	span.SetAttributes(attribute.KeyValue{Key: "User -> delivery -> ", Value: attribute.StringValue("PatchUser")})
+
+	if in.User == nil {
+		errValidation := entity.NewErrValidation()
+		errValidation.Err = errors.New("invalid update user request")
+		errValidation.Errors["user"] = "must be set"
+		return &pb.User{}, grpc.Error(ctx, errValidation)
+	}
 
-	user, err := d.userUsecase.Get(ctx, map[string]string{"id": in.UserId})
+	req, err := updateRequest(in.User)
 	if err != nil {
-		d.logger.Error("userUseCase.Get", zap.Error(err))
-		return &pb.UserModel{}, grpc.Error(ctx, err)
+		return &pb.User{}, grpc.Error(ctx, err)
 	}
 
-	return &pb.UserModel{
+	return d.update(ctx, req, in.UpdateMask.GetPaths())
+}
+
+// updateRequest maps the user of an update request, its etag becomes the expected version
+func updateRequest(in *pb.User) (*entity.User, error) {
+	version, err := parseEtag("user.etag", in.Etag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.User{
+		Version:   version,
+		Id:        in.Id,
+		Email:     in.Email,
+		Username:  in.Username,
+		FirstName: in.FirstName,
+		LastName:  in.LastName,
+		Bio:       in.Bio,
+		Website:   in.Website,
+		IsActive:  in.IsActive,
+	}, nil
+}
+
+func (d *userRPC) update(ctx context.Context, req *entity.User, fields []string) (*pb.User, error) {
+	user, err := d.userUsecase.Update(ctx, req, fields)
+	if err != nil {
+		d.logger.Error("userUseCase.Update", zap.Error(err))
+		return &pb.User{}, grpc.Error(ctx, err)
+	}
+
+	return &pb.User{
 		Id:        user.Id,
 		Username:  user.Username,
+		Email:     user.Email,
 		FirstName: user.FirstName,
 		LastName:  user.LastName,
 		Bio:       user.Bio,
 		Website:   user.Website,
+		IsActive:  user.IsActive,
 		CreatedAt: user.CreatedAt.String(),
 		UpdatedAt: user.UpdatedAt.String(),
+		Etag:      formatEtag(user.Version),
 	}, nil
 }
 
+func (d *userRPC) Get(ctx context.Context, in *pb.GetRequest) (*pb.UserModel, error) {
+	ctx, span := otlp.Start(ctx, serviceNameUser, spanNameUser+"Get")
+	defer span.End()
+
+	span.SetAttributes(attribute.KeyValue{Key: "User -> delivery -> ", Value: attribute.StringValue("Get")})
+
+	params := make(map[string]string, 3)
+	if in.UserId != "" {
+		params["id"] = in.UserId
+	}
+	if in.Email != "" {
+		params["email"] = in.Email
+	}
+	if in.Username != "" {
+		params["username"] = in.Username
+	}
+
+	user, err := d.userUsecase.Get(ctx, params)
+	if err != nil {
+		d.logger.Error("userUseCase.Get", zap.Error(err))
+		return &pb.UserModel{}, grpc.Error(ctx, err)
+	}
+
+	return toUserModel(user), nil
+}
+
 func (d *userRPC) Delete(ctx context.Context, in *pb.GetRequest) (*empty.Empty, error) {
 	ctx, span := otlp.Start(ctx, serviceNameUser, spanNameUser+"Delete")
 	defer span.End()
 
 	span.SetAttributes(attribute.KeyValue{Key: "User -> delivery -> ", Value: attribute.StringValue("Delete")})
 
-	err := d.userUsecase.Delete(ctx, in.UserId)
+	version, err := parseEtag("etag", in.Etag)
+	if err != nil {
+		return &empty.Empty{}, grpc.Error(ctx, err)
+	}
+
+	err = d.userUsecase.Delete(ctx, in.UserId, version)
 	if err != nil {
 		d.logger.Error("userUseCase.Delete", zap.Error(err))
 		return &empty.Empty{}, grpc.Error(ctx, err)
@@ -124,6 +187,21 @@ func (d *userRPC) Delete(ctx context.Context, in *pb.GetRequest) (*empty.Empty,
 	return &empty.Empty{}, nil
 }
 
+func (d *userRPC) Restore(ctx context.Context, in *pb.GetRequest) (*empty.Empty, error) {
+	ctx, span := otlp.Start(ctx, serviceNameUser, spanNameUser+"Restore")
+	defer span.End()
+
+	span.SetAttributes(attribute.KeyValue{Key: "User -> delivery -> ", Value: attribute.StringValue("Restore")})
+
+	err := d.userUsecase.Restore(ctx, in.UserId)
+	if err != nil {
+		d.logger.Error("userUseCase.Restore", zap.Error(err))
+		return &empty.Empty{}, grpc.Error(ctx, err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
 func (d *userRPC) List(ctx context.Context, in *pb.GetListFilter) (*pb.Users, error) {
 	ctx, span := otlp.Start(ctx, serviceNameUser, spanNameUser+"List")
 	defer span.End()
@@ -131,9 +209,37 @@ func (d *userRPC) List(ctx context.Context, in *pb.GetListFilter) (*pb.Users, er
 	span.SetAttributes(attribute.KeyValue{Key: "User -> delivery -> ", Value: attribute.StringValue("Get list")})
 
 	filter := &entity.GetListFilter{
-		Limit:   in.Limit,
-		Page:    in.Page,
-		OrderBy: in.OrderBy,
+		Limit:          in.Limit,
+		Page:           in.Page,
+		OrderBy:        in.OrderBy,
+		IncludeDeleted: in.IncludeDeleted,
+		Search:         in.Search,
+		PageToken:      in.PageToken,
+	}
+	if in.IsActive != nil {
+		isActive := in.IsActive.Value
+		filter.IsActive = &isActive
+	}
+
+	errValidation := entity.NewErrValidation()
+	if in.CreatedFrom != "" {
+		createdFrom, err := time.Parse(time.RFC3339, in.CreatedFrom)
+		if err != nil {
+			errValidation.Errors["created_from"] = "must be RFC3339 timestamp"
+		}
+		// created_at is stored without time zone in UTC, pgx drops the offset
+		filter.CreatedFrom = createdFrom.UTC()
+	}
+	if in.CreatedTo != "" {
+		createdTo, err := time.Parse(time.RFC3339, in.CreatedTo)
+		if err != nil {
+			errValidation.Errors["created_to"] = "must be RFC3339 timestamp"
+		}
+		filter.CreatedTo = createdTo.UTC()
+	}
+	if len(errValidation.Errors) != 0 {
+		errValidation.Err = errors.New("invalid list users request")
+		return &pb.Users{}, grpc.Error(ctx, errValidation)
 	}
 
 	users, err := d.userUsecase.List(ctx, filter)
@@ -143,19 +249,98 @@ func (d *userRPC) List(ctx context.Context, in *pb.GetListFilter) (*pb.Users, er
 	}
 
 	var pbUsers []*pb.UserModel
-	for _, user := range users {
-		pbUsers = append(pbUsers, &pb.UserModel{
-			Id:        user.Id,
-			Username:  user.Username,
-			FirstName: user.FirstName,
-			LastName:  user.LastName,
-			Bio:       user.Bio,
-			Website:   user.Website,
-			CreatedAt: user.CreatedAt.String(),
-			UpdatedAt: user.UpdatedAt.String(),
-			Posts:     []*pb.Post{},
-		})
-	}
-
-	return &pb.Users{Users: pbUsers, Count: int64(len(pbUsers))}, nil
+	for _, user := range users.Users {
+		pbUsers = append(pbUsers, toUserModel(user))
+	}
+
+	return &pb.Users{Users: pbUsers, Count: users.Count, NextPageToken: users.NextPageToken}, nil
+}
+
+func (d *userRPC) CheckField(ctx context.Context, in *pb.CheckFieldReq) (*pb.Status, error) {
+	ctx, span := otlp.Start(ctx, serviceNameUser, spanNameUser+"CheckField")
+	defer span.End()
+
+	span.SetAttributes(attribute.KeyValue{Key: "User -> delivery -> ", Value: attribute.StringValue("CheckField")})
+
+	available, err := d.userUsecase.CheckField(ctx, in.Field, in.Value)
+	if err != nil {
+		d.logger.Error("userUseCase.CheckField", zap.Error(err))
+		return &pb.Status{}, grpc.Error(ctx, err)
+	}
+
+	return &pb.Status{Status: available}, nil
+}
+
+func (d *userRPC) UpdateRefreshToken(ctx context.Context, in *pb.UpdateRefreshReq) (*empty.Empty, error) {
+	ctx, span := otlp.Start(ctx, serviceNameUser, spanNameUser+"UpdateRefreshToken")
+	defer span.End()
+
+	span.SetAttributes(attribute.KeyValue{Key: "User -> delivery -> ", Value: attribute.StringValue("UpdateRefreshToken")})
+
+	if err := d.userUsecase.UpdateRefreshToken(ctx, in.UserId, in.RefreshToken); err != nil {
+		d.logger.Error("userUseCase.UpdateRefreshToken", zap.Error(err))
+		return &empty.Empty{}, grpc.Error(ctx, err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (d *userRPC) VerifyCredentials(ctx context.Context, in *pb.VerifyCredentialsReq) (*pb.UserModel, error) {
+	ctx, span := otlp.Start(ctx, serviceNameUser, spanNameUser+"VerifyCredentials")
+	defer span.End()
+
+	span.SetAttributes(attribute.KeyValue{Key: "User -> delivery -> ", Value: attribute.StringValue("VerifyCredentials")})
+
+	user, err := d.userUsecase.VerifyCredentials(ctx, in.Login, in.Password)
+	if err != nil {
+		d.logger.Error("userUseCase.VerifyCredentials", zap.Error(err))
+		return &pb.UserModel{}, grpc.Error(ctx, err)
+	}
+
+	return toUserModel(user), nil
+}
+
+// toUserModel maps user to its public representation, password and refresh
+// token hashes are never sent to clients
+func toUserModel(user *entity.User) *pb.UserModel {
+	var deletedAt string
+	if !user.DeletedAt.IsZero() {
+		deletedAt = user.DeletedAt.String()
+	}
+	return &pb.UserModel{
+		Id:        user.Id,
+		Username:  user.Username,
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Bio:       user.Bio,
+		Website:   user.Website,
+		IsActive:  user.IsActive,
+		CreatedAt: user.CreatedAt.String(),
+		UpdatedAt: user.UpdatedAt.String(),
+		DeletedAt: deletedAt,
+		Etag:      formatEtag(user.Version),
+		Posts:     []*pb.Post{},
+	}
+}
+
+// formatEtag exposes user version to clients as an opaque etag
+func formatEtag(version int64) string {
+	return strconv.FormatInt(version, 10)
+}
+
+// parseEtag returns the version the client expects, 0 when etag is not given
+func parseEtag(field, etag string) (int64, error) {
+	if etag == "" {
+		return 0, nil
+	}
+
+	version, err := strconv.ParseInt(etag, 10, 64)
+	if err != nil || version <= 0 {
+		errValidation := entity.NewErrValidation()
+		errValidation.Err = errors.New("invalid etag")
+		errValidation.Errors[field] = "must be an etag returned by Get"
+		return 0, errValidation
+	}
+	return version, nil
 }
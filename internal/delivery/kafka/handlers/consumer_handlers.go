@@ -2,81 +2,91 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	pb "fourth-exam/user-service-evrone/genproto/user_service"
-	"fourth-exam/user-service-evrone/internal/entity"
 	"fourth-exam/user-service-evrone/internal/infrastructure/kafka"
+	"fourth-exam/user-service-evrone/internal/pkg/codec"
 	"fourth-exam/user-service-evrone/internal/pkg/config"
 	"fourth-exam/user-service-evrone/internal/usecase"
 	"fourth-exam/user-service-evrone/internal/usecase/event"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
-	"github.com/k0kubun/pp"
 )
 
+// UserCommandsConsumer applies requests of other services to change users,
+// it reads KAFKA_TOPIC_USER_SERVICE and not KAFKA_TOPIC_USER_EVENTS, where
+// this service publishes its own user events
+const UserCommandsConsumer = "user_commands"
+
+func init() {
+	Register(Registration{
+		Name: UserCommandsConsumer,
+		// deployments run the consumer by the name it had before the registry
+		Aliases:     []string{"user_create_consumer"},
+		Description: "applies user create, update, delete and activation commands of KAFKA_TOPIC_USER_SERVICE",
+		Settings: func(conf *config.Config) Settings {
+			return Settings{
+				Topic: conf.Kafka.Topic.UserTopic,
+				// group the consumer committed offsets under before it was configurable
+				GroupID:         "1",
+				DeadLetterTopic: conf.Kafka.Topic.UserTopicDeadLetter,
+			}
+		},
+		Handler: func(deps Dependencies, settings Settings) kafka.HandlerFunc {
+			return NewUserConsumerHandler(settings.GroupID, deps).handleUserEvent
+		},
+	})
+}
+
 type userConsumerHandler struct {
-	config         *config.Config
-	brokerConsumer event.BrokerConsumer
-	logger         *zap.Logger
-	userUsecase    usecase.User
+	groupID      string
+	logger       *zap.Logger
+	userUsecase  usecase.User
+	inboxUsecase usecase.Inbox
+	codecs       *codec.Registry
+	handlers     map[string]userEventHandler
 }
 
-func NewUserConsumerHandler(conf *config.Config,
-	brokerConsumer event.BrokerConsumer,
-	logger *zap.Logger,
-	userUseCase usecase.User) *userConsumerHandler {
-	return &userConsumerHandler{
-		config:         conf,
-		brokerConsumer: brokerConsumer,
-		logger:         logger,
-		userUsecase:    userUseCase,
+// NewUserConsumerHandler handles user events consumed by groupID, processed
+// messages are kept per group so groups of the same topic do not skip each other's
+func NewUserConsumerHandler(groupID string, deps Dependencies) *userConsumerHandler {
+	u := &userConsumerHandler{
+		groupID:      groupID,
+		logger:       deps.Logger,
+		userUsecase:  deps.UserUsecase,
+		inboxUsecase: deps.InboxUsecase,
+		codecs:       deps.Codecs,
 	}
+	u.handlers = u.userEventHandlers()
+	return u
 }
 
-func (u *userConsumerHandler) HandlerEvents() error {
-	consumerConfig := kafka.NewConsumerConfig(
-		u.config.Kafka.Address,
-		u.config.Kafka.Topic.UserTopic,
-		"1",
-		func(ctx context.Context, key, value []byte) error {
-			var user pb.User
-
-			if err := json.Unmarshal(value, &user); err != nil {
-				return err
-			}
-
-			pp.Println(user)
-
-			req := entity.User{
-				Id:           user.Id,
-				Username:     user.Username,
-				FirstName:    user.FirstName,
-				LastName:     user.LastName,
-				Bio:          user.Bio,
-				Website:      user.Website,
-				IsActive:     user.IsActive,
-				RefreshToken: user.RefreshToken,
-				Email:        user.Email,
-			}
-
-			ctxNew, err := context.WithTimeout(context.Background(), time.Second*7)
-			if err != nil {
-				fmt.Println(err, "Context error")
-			}
-			_, errr := u.userUsecase.Create(ctxNew, &req)
-			if errr != nil {
-				fmt.Println(errr, "Create=========================")
-			}
-			// fmt.Println(req, "user")
-
-			return nil
-		},
+// handleUserEvent applies the user event of the message once per message,
+// redelivered messages are skipped
+func (u *userConsumerHandler) handleUserEvent(ctx context.Context, message event.Message) error {
+	userEvent, handler, err := decodeUserEvent(message, u.codecs, u.handlers)
+	if err != nil {
+		return err
+	}
+	trace.SpanFromContext(ctx).SetAttributes(
+		attribute.String("user_event.type", userEvent.EventType),
+		attribute.String("user_event.occurred_at", userEvent.OccurredAt),
 	)
 
-	u.brokerConsumer.RegisterConsumer(consumerConfig)
-	u.brokerConsumer.Run()
+	// ctx carries the span of the message, so the user is changed within the producer's trace
+	ctx, cancel := context.WithTimeout(ctx, time.Second*7)
+	defer cancel()
+
+	processed, err := u.inboxUsecase.Process(ctx, u.groupID, message.ID(), func(ctx context.Context) error {
+		return handler(ctx, userEvent.Payload)
+	})
+	if err != nil {
+		return err
+	}
+	if !processed {
+		u.logger.Info("user consumer skipped processed message", zap.String("group_id", u.groupID), zap.String("message_id", message.ID()), zap.String("event_type", userEvent.EventType))
+	}
 
 	return nil
 }
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"fmt"
+	"fourth-exam/user-service-evrone/internal/infrastructure/kafka"
+	"fourth-exam/user-service-evrone/internal/pkg/codec"
+	"fourth-exam/user-service-evrone/internal/pkg/config"
+	"fourth-exam/user-service-evrone/internal/usecase"
+	"fourth-exam/user-service-evrone/internal/usecase/event"
+	"sort"
+	"strconv"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// Dependencies are shared by handlers of every consumer run in the process
+type Dependencies struct {
+	Config       *config.Config
+	Logger       *zap.Logger
+	UserUsecase  usecase.User
+	InboxUsecase usecase.Inbox
+	Codecs       *codec.Registry
+}
+
+// Settings of a consumer, defaults come from the registration and every
+// field may be overridden by env KAFKA_CONSUMER_<NAME>_<KEY>
+type Settings struct {
+	Topic           string // TOPIC
+	GroupID         string // GROUP_ID
+	StartOffset     string // START_OFFSET, first or last
+	Workers         string // WORKERS
+	DeadLetterTopic string // DEAD_LETTER_TOPIC
+}
+
+// Registration describes a consumer that can be run by name
+type Registration struct {
+	Name string
+	// Aliases are former names that still run the consumer, env overrides
+	// are read under Name only
+	Aliases     []string
+	Description string
+	// Settings returns default settings of the consumer
+	Settings func(conf *config.Config) Settings
+	// Handler builds the handler of the consumer messages with resolved settings
+	Handler func(deps Dependencies, settings Settings) kafka.HandlerFunc
+}
+
+var registry = struct {
+	sync.Mutex
+	consumers map[string]Registration
+	aliases   map[string]string
+}{consumers: make(map[string]Registration), aliases: make(map[string]string)}
+
+// Register adds the consumer to the registry, names and aliases are unique
+func Register(registration Registration) {
+	registry.Lock()
+	defer registry.Unlock()
+
+	for _, name := range append([]string{registration.Name}, registration.Aliases...) {
+		_, registered := registry.consumers[name]
+		_, aliased := registry.aliases[name]
+		if registered || aliased {
+			panic(fmt.Sprintf("consumer '%s' is already registered", name))
+		}
+	}
+	registry.consumers[registration.Name] = registration
+	for _, alias := range registration.Aliases {
+		registry.aliases[alias] = registration.Name
+	}
+}
+
+// Consumers returns registered consumers sorted by name
+func Consumers() []Registration {
+	registry.Lock()
+	defer registry.Unlock()
+
+	consumers := make([]Registration, 0, len(registry.consumers))
+	for _, registration := range registry.consumers {
+		consumers = append(consumers, registration)
+	}
+	sort.Slice(consumers, func(i, j int) bool {
+		return consumers[i].Name < consumers[j].Name
+	})
+	return consumers
+}
+
+// Lookup returns the consumer registered under name or alias
+func Lookup(name string) (Registration, bool) {
+	registry.Lock()
+	defer registry.Unlock()
+
+	if target, ok := registry.aliases[name]; ok {
+		name = target
+	}
+	registration, ok := registry.consumers[name]
+	return registration, ok
+}
+
+// Resolve returns the consumer settings with env overrides applied
+func (r Registration) Resolve(conf *config.Config) Settings {
+	settings := r.Settings(conf)
+	if settings.StartOffset == "" {
+		settings.StartOffset = event.OffsetFirst
+	}
+	if settings.Workers == "" {
+		settings.Workers = conf.Kafka.Consumer.Workers
+	}
+
+	return Settings{
+		Topic:           conf.ConsumerEnv(r.Name, "TOPIC", settings.Topic),
+		GroupID:         conf.ConsumerEnv(r.Name, "GROUP_ID", settings.GroupID),
+		StartOffset:     conf.ConsumerEnv(r.Name, "START_OFFSET", settings.StartOffset),
+		Workers:         conf.ConsumerEnv(r.Name, "WORKERS", settings.Workers),
+		DeadLetterTopic: conf.ConsumerEnv(r.Name, "DEAD_LETTER_TOPIC", settings.DeadLetterTopic),
+	}
+}
+
+// ConsumerConfig builds the broker consumer config of the registered consumer
+func (r Registration) ConsumerConfig(deps Dependencies) (*kafka.ConsumerConfig, error) {
+	settings := r.Resolve(deps.Config)
+	if settings.Topic == "" {
+		return nil, fmt.Errorf("consumer '%s' has no topic", r.Name)
+	}
+	if settings.GroupID == "" {
+		return nil, fmt.Errorf("consumer '%s' has no group id", r.Name)
+	}
+	if settings.StartOffset != event.OffsetFirst && settings.StartOffset != event.OffsetLast {
+		return nil, fmt.Errorf("invalid start offset '%s' of consumer '%s'", settings.StartOffset, r.Name)
+	}
+
+	workers, err := strconv.Atoi(settings.Workers)
+	if err != nil || workers <= 0 {
+		return nil, fmt.Errorf("invalid workers '%s' of consumer '%s'", settings.Workers, r.Name)
+	}
+
+	retryPolicy, err := kafka.NewRetryPolicy(deps.Config, settings.DeadLetterTopic)
+	if err != nil {
+		return nil, err
+	}
+
+	return kafka.NewConsumerConfig(
+		deps.Config.Kafka.Address,
+		settings.Topic,
+		settings.GroupID,
+		settings.StartOffset,
+		r.Handler(deps, settings),
+		retryPolicy,
+		workers,
+	), nil
+}
@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	pb "fourth-exam/user-service-evrone/genproto/user_service"
+	"fourth-exam/user-service-evrone/internal/entity"
+	"fourth-exam/user-service-evrone/internal/pkg/codec"
+	"fourth-exam/user-service-evrone/internal/usecase/event"
+	"time"
+)
+
+const (
+	userEventCreate     = "user.create"
+	userEventUpdate     = "user.update"
+	userEventDelete     = "user.delete"
+	userEventActivate   = "user.activate"
+	userEventDeactivate = "user.deactivate"
+
+	// userEventVersion is the only envelope version understood
+	userEventVersion = 1
+)
+
+// userEventHandler applies payload of pb.UserEvent, messages of the user
+// topic are pb.UserEvent encoded as told by their content-type header, e.g.
+//
+//	{"event_type": "user.update", "version": 1, "occurred_at": "2024-05-01T10:00:00Z",
+//	 "payload": {"user": {"id": "...", "bio": "..."}, "update_mask": {"paths": ["bio"]}}}
+type userEventHandler func(ctx context.Context, payload *pb.UserEventPayload) error
+
+func (u *userConsumerHandler) userEventHandlers() map[string]userEventHandler {
+	return map[string]userEventHandler{
+		userEventCreate:     u.createUser,
+		userEventUpdate:     u.updateUser,
+		userEventDelete:     u.deleteUser,
+		userEventActivate:   u.setUserActive(true),
+		userEventDeactivate: u.setUserActive(false),
+	}
+}
+
+// decodeUserEvent decodes the message with the codec of its content type,
+// messages that can never be handled are reported as permanent errors so
+// they are dead-lettered right away
+func decodeUserEvent(message event.Message, codecs *codec.Registry, handlers map[string]userEventHandler) (*pb.UserEvent, userEventHandler, error) {
+	contentType := message.Header(event.HeaderContentType)
+	decoder, err := codecs.Get(contentType)
+	if err != nil {
+		return nil, nil, event.NewPermanentError(err)
+	}
+
+	if contentType == "" && isLegacyUser(message.Value) {
+		return decodeLegacyUser(message, decoder, handlers)
+	}
+
+	var userEvent pb.UserEvent
+	if err := decoder.Unmarshal(message.Value, &userEvent); err != nil {
+		return nil, nil, event.NewPermanentError(fmt.Errorf("decode user event as %s: %w", decoder.ContentType(), err))
+	}
+
+	if userEvent.Version != userEventVersion {
+		return nil, nil, event.NewPermanentError(fmt.Errorf("unsupported user event version %d", userEvent.Version))
+	}
+
+	if _, err := time.Parse(time.RFC3339, userEvent.OccurredAt); err != nil {
+		return nil, nil, event.NewPermanentError(fmt.Errorf("%s event has invalid occurred_at %q: %w", userEvent.EventType, userEvent.OccurredAt, err))
+	}
+
+	handler, ok := handlers[userEvent.EventType]
+	if !ok {
+		return nil, nil, event.NewPermanentError(fmt.Errorf("unknown user event type %q", userEvent.EventType))
+	}
+
+	if userEvent.Payload == nil {
+		return nil, nil, event.NewPermanentError(fmt.Errorf("%s event has no payload", userEvent.EventType))
+	}
+
+	return &userEvent, handler, nil
+}
+
+// isLegacyUser reports whether value is a bare JSON pb.User as producers
+// sent it before the envelope, such messages have no content type header
+func isLegacyUser(value []byte) bool {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(value, &fields); err != nil {
+		return false
+	}
+
+	for _, key := range []string{"event_type", "eventType", "version"} {
+		if _, ok := fields[key]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+// decodeLegacyUser wraps a bare pb.User into a create event, legacy
+// producers only ever asked for users to be created
+func decodeLegacyUser(message event.Message, decoder codec.Codec, handlers map[string]userEventHandler) (*pb.UserEvent, userEventHandler, error) {
+	var user pb.User
+	if err := decoder.Unmarshal(message.Value, &user); err != nil {
+		return nil, nil, event.NewPermanentError(fmt.Errorf("decode legacy user as %s: %w", decoder.ContentType(), err))
+	}
+
+	userEvent := &pb.UserEvent{
+		EventType: userEventCreate,
+		Version:   userEventVersion,
+		Payload:   &pb.UserEventPayload{User: &user},
+	}
+	if !message.Time.IsZero() {
+		userEvent.OccurredAt = message.Time.UTC().Format(time.RFC3339Nano)
+	}
+	return userEvent, handlers[userEventCreate], nil
+}
+
+// permanent marks errors retrying can not fix as permanent, a missing or
+// soft deleted user does not appear by waiting for it
+func permanent(err error) error {
+	var (
+		errValidation *entity.ErrValidation
+		errConflict   *entity.ErrConflict
+		errNotFound   *entity.ErrNotFound
+	)
+	if errors.As(err, &errValidation) || errors.As(err, &errConflict) || errors.As(err, &errNotFound) {
+		return event.NewPermanentError(err)
+	}
+	return err
+}
+
+func (u *userConsumerHandler) createUser(ctx context.Context, payload *pb.UserEventPayload) error {
+	user := payload.User
+	if user == nil {
+		return event.NewPermanentError(errors.New("create event has no user"))
+	}
+
+	req := entity.User{
+		Id:           user.Id,
+		Username:     user.Username,
+		FirstName:    user.FirstName,
+		LastName:     user.LastName,
+		Bio:          user.Bio,
+		Website:      user.Website,
+		IsActive:     user.IsActive,
+		RefreshToken: user.RefreshToken,
+		Email:        user.Email,
+	}
+
+	_, err := u.userUsecase.Upsert(ctx, &req)
+	return permanent(err)
+}
+
+func (u *userConsumerHandler) updateUser(ctx context.Context, payload *pb.UserEventPayload) error {
+	user := payload.User
+	if user == nil {
+		return event.NewPermanentError(errors.New("update event has no user"))
+	}
+
+	req := entity.User{
+		Id:        user.Id,
+		Username:  user.Username,
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Bio:       user.Bio,
+		Website:   user.Website,
+		IsActive:  user.IsActive,
+	}
+
+	var fields []string
+	if payload.UpdateMask != nil {
+		fields = payload.UpdateMask.Paths
+	}
+
+	_, err := u.userUsecase.Update(ctx, &req, fields)
+	return permanent(err)
+}
+
+// deleteUser soft deletes the user, a user already gone counts as deleted
+func (u *userConsumerHandler) deleteUser(ctx context.Context, payload *pb.UserEventPayload) error {
+	if payload.UserId == "" {
+		return event.NewPermanentError(errors.New("delete event has no user_id"))
+	}
+
+	var errNotFound *entity.ErrNotFound
+	if err := u.userUsecase.Delete(ctx, payload.UserId, 0); err != nil && !errors.As(err, &errNotFound) {
+		return permanent(err)
+	}
+	return nil
+}
+
+func (u *userConsumerHandler) setUserActive(active bool) userEventHandler {
+	return func(ctx context.Context, payload *pb.UserEventPayload) error {
+		if payload.UserId == "" {
+			return event.NewPermanentError(errors.New("activation event has no user_id"))
+		}
+
+		_, err := u.userUsecase.Update(ctx, &entity.User{Id: payload.UserId, IsActive: active}, []string{"is_active"})
+		return permanent(err)
+	}
+}
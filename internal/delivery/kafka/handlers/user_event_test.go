@@ -0,0 +1,313 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	pb "fourth-exam/user-service-evrone/genproto/user_service"
+	"fourth-exam/user-service-evrone/internal/entity"
+	"fourth-exam/user-service-evrone/internal/infrastructure/kafka"
+	"fourth-exam/user-service-evrone/internal/infrastructure/memory"
+	"fourth-exam/user-service-evrone/internal/pkg/codec"
+	"fourth-exam/user-service-evrone/internal/usecase"
+	"fourth-exam/user-service-evrone/internal/usecase/event"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	testTopic   = "users"
+	testGroupID = "user-service"
+	testTimeout = 5 * time.Second
+)
+
+// fakeUsers records users updated by events, block makes the first update
+// wait until its context is done
+type fakeUsers struct {
+	usecase.User
+
+	block    bool
+	blocked  chan struct{}
+	updated  chan string
+	upserted chan *entity.User
+}
+
+func newFakeUsers(block bool) *fakeUsers {
+	return &fakeUsers{
+		block:    block,
+		blocked:  make(chan struct{}),
+		updated:  make(chan string, 16),
+		upserted: make(chan *entity.User, 16),
+	}
+}
+
+func (u *fakeUsers) Update(ctx context.Context, req *entity.User, fields []string) (*entity.User, error) {
+	if u.block {
+		u.block = false
+		close(u.blocked)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	u.updated <- req.Id
+	return req, nil
+}
+
+func (u *fakeUsers) Upsert(ctx context.Context, req *entity.User) (*entity.User, error) {
+	u.upserted <- req
+	return req, nil
+}
+
+// fakeInbox runs fn once per message id like the inbox of the database
+type fakeInbox struct {
+	usecase.Inbox
+
+	mu        sync.Mutex
+	processed map[string]bool
+}
+
+func (i *fakeInbox) Process(ctx context.Context, consumer, messageID string, fn func(ctx context.Context) error) (bool, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.processed[consumer+"/"+messageID] {
+		return false, nil
+	}
+	if err := fn(ctx); err != nil {
+		return false, err
+	}
+	i.processed[consumer+"/"+messageID] = true
+	return true, nil
+}
+
+// runConsumer runs a member of the user events group on broker, the
+// returned function closes it within closeTimeout and returns the Close error
+func runConsumer(t *testing.T, broker *memory.Broker, users usecase.User, inbox usecase.Inbox, closeTimeout time.Duration) func() error {
+	t.Helper()
+
+	deps := Dependencies{
+		Logger:       zap.NewNop(),
+		UserUsecase:  users,
+		InboxUsecase: inbox,
+		Codecs:       codec.New(),
+	}
+	handler := NewUserConsumerHandler(testGroupID, deps).handleUserEvent
+
+	consumer := memory.NewConsumer(zap.NewNop(), broker, memory.NewProducer(broker, testTopic))
+	consumer.RegisterConsumer(kafka.NewConsumerConfig(nil, testTopic, testGroupID, event.OffsetFirst, handler, event.RetryPolicy{MaxAttempts: 1}, 1))
+
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- consumer.Run(context.Background())
+	}()
+
+	return func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), closeTimeout)
+		defer cancel()
+
+		err := consumer.Close(ctx)
+		if err := <-runErr; err != nil {
+			t.Fatalf("run consumer: %v", err)
+		}
+		return err
+	}
+}
+
+func produceUserUpdate(t *testing.T, broker *memory.Broker, userID string) {
+	t.Helper()
+
+	value, err := codec.Protobuf{}.Marshal(&pb.UserEvent{
+		EventType:  userEventUpdate,
+		Version:    userEventVersion,
+		OccurredAt: "2024-05-01T10:00:00Z",
+		Payload:    &pb.UserEventPayload{User: &pb.User{Id: userID, Bio: "bio"}},
+	})
+	if err != nil {
+		t.Fatalf("marshal user event: %v", err)
+	}
+
+	err = memory.NewProducer(broker, testTopic).Produce(context.Background(), event.Message{
+		Key:     []byte(userID),
+		Value:   value,
+		Headers: []event.Header{{Key: event.HeaderContentType, Value: []byte(codec.ContentTypeProtobuf)}},
+	})
+	if err != nil {
+		t.Fatalf("produce user event: %v", err)
+	}
+}
+
+func waitUpdated(t *testing.T, users *fakeUsers, userID string) {
+	t.Helper()
+
+	select {
+	case id := <-users.updated:
+		if id != userID {
+			t.Fatalf("updated user %s, want %s", id, userID)
+		}
+	case <-time.After(testTimeout):
+		t.Fatalf("user %s was not updated", userID)
+	}
+}
+
+func waitCommitted(t *testing.T, broker *memory.Broker, offset int64) {
+	t.Helper()
+
+	deadline := time.Now().Add(testTimeout)
+	for {
+		if committed, ok := broker.Committed(testGroupID, testTopic, 0); ok && committed == offset {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("offset %d was not committed", offset)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestUserConsumerCommittedMessageIsNotRedelivered(t *testing.T) {
+	var (
+		broker = memory.NewBroker(1)
+		users  = newFakeUsers(false)
+		inbox  = &fakeInbox{processed: make(map[string]bool)}
+	)
+
+	produceUserUpdate(t, broker, "first")
+	closeConsumer := runConsumer(t, broker, users, inbox, testTimeout)
+	waitUpdated(t, users, "first")
+	waitCommitted(t, broker, 1)
+	if err := closeConsumer(); err != nil {
+		t.Fatalf("close consumer: %v", err)
+	}
+
+	// a new member resumes after the committed message, its inbox is empty so
+	// a redelivered message would update the user again
+	closeConsumer = runConsumer(t, broker, users, &fakeInbox{processed: make(map[string]bool)}, testTimeout)
+	produceUserUpdate(t, broker, "second")
+	waitUpdated(t, users, "second")
+	waitCommitted(t, broker, 2)
+	if err := closeConsumer(); err != nil {
+		t.Fatalf("close consumer: %v", err)
+	}
+
+	if len(users.updated) != 0 {
+		t.Fatalf("user %s updated again", <-users.updated)
+	}
+}
+
+func TestUserConsumerUncommittedMessageIsRedelivered(t *testing.T) {
+	var (
+		broker = memory.NewBroker(1)
+		users  = newFakeUsers(true)
+		inbox  = &fakeInbox{processed: make(map[string]bool)}
+	)
+
+	produceUserUpdate(t, broker, "first")
+	closeConsumer := runConsumer(t, broker, users, inbox, 10*time.Millisecond)
+	select {
+	case <-users.blocked:
+	case <-time.After(testTimeout):
+		t.Fatal("user was not updated")
+	}
+
+	// the handler outlives the shutdown deadline, so it is aborted and the member leaves
+	if err := closeConsumer(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("close consumer: got %v, want %v", err, context.DeadlineExceeded)
+	}
+	if offset, ok := broker.Committed(testGroupID, testTopic, 0); ok {
+		t.Fatalf("aborted message committed offset %d", offset)
+	}
+
+	// a new member of the group gets the message again
+	closeConsumer = runConsumer(t, broker, users, inbox, testTimeout)
+	waitUpdated(t, users, "first")
+	waitCommitted(t, broker, 1)
+	if err := closeConsumer(); err != nil {
+		t.Fatalf("close consumer: %v", err)
+	}
+}
+
+func TestUserConsumerAcceptsLegacyUser(t *testing.T) {
+	var (
+		users   = newFakeUsers(false)
+		handler = NewUserConsumerHandler(testGroupID, Dependencies{
+			Logger:       zap.NewNop(),
+			UserUsecase:  users,
+			InboxUsecase: &fakeInbox{processed: make(map[string]bool)},
+			Codecs:       codec.New(),
+		})
+	)
+
+	// producers written before the envelope send a bare pb.User without content type
+	message := event.Message{
+		Topic: testTopic,
+		Value: []byte(`{"id":"6f1c","username":"john","email":"john@example.com","first_name":"John","last_name":"Doe","bio":"bio","website":"https://example.com","is_active":true}`),
+	}
+	if err := handler.handleUserEvent(context.Background(), message); err != nil {
+		t.Fatalf("handle legacy user: %v", err)
+	}
+
+	select {
+	case user := <-users.upserted:
+		want := entity.User{
+			Id:        "6f1c",
+			Username:  "john",
+			Email:     "john@example.com",
+			FirstName: "John",
+			LastName:  "Doe",
+			Bio:       "bio",
+			Website:   "https://example.com",
+			IsActive:  true,
+		}
+		if *user != want {
+			t.Fatalf("created user %+v, want %+v", *user, want)
+		}
+	default:
+		t.Fatal("legacy user was not created")
+	}
+}
+
+func TestDecodeUserEventOccurredAt(t *testing.T) {
+	handlers := NewUserConsumerHandler(testGroupID, Dependencies{}).handlers
+
+	tests := []struct {
+		name       string
+		occurredAt string
+		wantErr    bool
+	}{
+		{name: "RFC3339", occurredAt: "2024-05-01T10:00:00Z"},
+		{name: "RFC3339 with fraction and offset", occurredAt: "2024-05-01T10:00:00.123+03:00"},
+		{name: "missing", occurredAt: "", wantErr: true},
+		{name: "malformed", occurredAt: "01.05.2024 10:00", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := codec.JSON{}.Marshal(&pb.UserEvent{
+				EventType:  userEventDelete,
+				Version:    userEventVersion,
+				OccurredAt: tt.occurredAt,
+				Payload:    &pb.UserEventPayload{UserId: "6f1c"},
+			})
+			if err != nil {
+				t.Fatalf("marshal user event: %v", err)
+			}
+			message := event.Message{
+				Value:   value,
+				Headers: []event.Header{{Key: event.HeaderContentType, Value: []byte(codec.ContentTypeJSON)}},
+			}
+
+			_, _, err = decodeUserEvent(message, codec.New(), handlers)
+			if tt.wantErr {
+				if !event.IsPermanent(err) {
+					t.Fatalf("decodeUserEvent() error = %v, want a permanent error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeUserEvent() error = %v", err)
+			}
+		})
+	}
+}
@@ -2,12 +2,14 @@ package entity
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 )
 
 var (
-	ErrorConflict = NewErrConflict("object")
-	ErrorNotFound = NewErrNotFound("object")
+	ErrorConflict           = NewErrConflict("object")
+	ErrorNotFound           = NewErrNotFound("object")
+	ErrorInvalidCredentials = NewErrUnauthenticated("invalid login or password")
 )
 
 // error not found
@@ -25,15 +27,51 @@ func NewErrNotFound(text string) *ErrNotFound {
 
 // error conflict
 type ErrConflict struct {
-	name string
+	name  string
+	field string
 }
 
 func (e *ErrConflict) Error() string {
 	return e.name + " already exist"
 }
 
+// Field returns the field which value collided, empty when unknown
+func (e *ErrConflict) Field() string {
+	return e.field
+}
+
 func NewErrConflict(text string) *ErrConflict {
-	return &ErrConflict{text}
+	return &ErrConflict{name: text}
+}
+
+func NewErrFieldConflict(text, field string) *ErrConflict {
+	return &ErrConflict{name: text + " with this " + field, field: field}
+}
+
+// error version mismatch
+type ErrVersionMismatch struct {
+	name string
+}
+
+func (e *ErrVersionMismatch) Error() string {
+	return e.name + " was modified concurrently"
+}
+
+func NewErrVersionMismatch(text string) *ErrVersionMismatch {
+	return &ErrVersionMismatch{text}
+}
+
+// error unauthenticated
+type ErrUnauthenticated struct {
+	reason string
+}
+
+func (e *ErrUnauthenticated) Error() string {
+	return e.reason
+}
+
+func NewErrUnauthenticated(reason string) *ErrUnauthenticated {
+	return &ErrUnauthenticated{reason}
 }
 
 // error validation
@@ -43,7 +81,27 @@ type ErrValidation struct {
 }
 
 func (e ErrValidation) Error() string {
-	return e.Err.Error()
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+
+	fields := make([]string, 0, len(e.Errors))
+	for field := range e.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var str strings.Builder
+	str.WriteString("validation failed")
+	for i, field := range fields {
+		if i == 0 {
+			str.WriteString(": ")
+		} else {
+			str.WriteString(", ")
+		}
+		str.WriteString(field + " " + e.Errors[field])
+	}
+	return str.String()
 }
 
 func NewErrValidation() *ErrValidation {
@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+const (
+	EventUserCreated = "user.created"
+	EventUserUpdated = "user.updated"
+	EventUserDeleted = "user.deleted"
+)
+
+// OutboxEvent is a domain event stored in the same transaction as the change
+// it describes and published to the broker afterwards
+type OutboxEvent struct {
+	ID          int64
+	EventID     string
+	AggregateID string
+	EventType   string
+	Payload     []byte
+	// TraceContext links the published event to the trace of the change
+	TraceContext map[string]string
+	CreatedAt    time.Time
+}
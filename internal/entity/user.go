@@ -15,10 +15,33 @@ type User struct {
 	RefreshToken string
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
+	DeletedAt    time.Time
+	// Version is bumped on every change, 0 in a request skips the version check
+	Version int64
 }
 
 type GetListFilter struct {
-	Page    int64  `json:"page"`
-	Limit   int64  `json:"limit"`
-	OrderBy string `json:"order_by"`
+	Page           int64     `json:"page"`
+	Limit          int64     `json:"limit"`
+	OrderBy        string    `json:"order_by"`
+	IncludeDeleted bool      `json:"include_deleted"`
+	IsActive       *bool     `json:"is_active"`
+	CreatedFrom    time.Time `json:"created_from"`
+	CreatedTo      time.Time `json:"created_to"`
+	Search         string    `json:"search"`
+	PageToken      string    `json:"page_token"`
+	// Sort is OrderBy parsed and checked against the sortable fields
+	Sort []SortField `json:"-"`
+}
+
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+type UserList struct {
+	Users []*User
+	// Count is the total number of users matching the filter
+	Count         int64
+	NextPageToken string
 }
\ No newline at end of file
@@ -3,7 +3,12 @@ package kafka
 import (
 	"context"
 	"fmt"
+	"fourth-exam/user-service-evrone/internal/infrastructure/messaging"
+	"fourth-exam/user-service-evrone/internal/pkg/config"
+	"fourth-exam/user-service-evrone/internal/pkg/metrics"
 	"fourth-exam/user-service-evrone/internal/usecase/event"
+	"strconv"
+	"time"
 
 	"github.com/segmentio/kafka-go"
 	"go.uber.org/zap"
@@ -14,91 +19,184 @@ const (
 	MaxBytes = 10e6 // 10MB
 )
 
-type HandlerFunc func(ctx context.Context, key, value []byte) error
+// readerRestartPolicy spaces out restarts of a reader that keeps failing to
+// fetch, the reader gives up after MaxAttempts failures in a row
+var readerRestartPolicy = event.RetryPolicy{MaxAttempts: 10, InitialBackoff: time.Second, MaxBackoff: time.Minute}
+
+type HandlerFunc func(ctx context.Context, message event.Message) error
 
 type consumer struct {
-	logger          *zap.Logger
-	consumerConfigs []event.ConsumerConfig
-	readers         []*kafka.Reader
+	*messaging.Dispatcher
+	logger *zap.Logger
 }
 
-func NewConsumer(logger *zap.Logger) *consumer {
+// NewConsumer uses producer to write messages to dead-letter topics
+func NewConsumer(logger *zap.Logger, producer event.BrokerProducer) *consumer {
 	return &consumer{
-		logger: logger,
+		Dispatcher: messaging.NewDispatcher("kafka", logger, producer),
+		logger:     logger,
 	}
 }
 
-func (c *consumer) RegisterConsumer(consumerConfig event.ConsumerConfig) {
-	c.consumerConfigs = append(c.consumerConfigs, consumerConfig)
+// Run consumes all registered topics until ctx is done or Close is called
+// and returns once every reader has exited, with the errors of readers
+// that gave up on their own
+func (c *consumer) Run(ctx context.Context) error {
+	return c.RunReaders(ctx, c.runReader)
 }
 
-func (c *consumer) Run() error {
-	for _, consumerConfig := range c.consumerConfigs {
-		r := kafka.NewReader(kafka.ReaderConfig{
-			Brokers:  consumerConfig.GetBrokers(),
-			Topic:    consumerConfig.GetTopic(),
-			GroupID:  consumerConfig.GetGroupID(),
-			MinBytes: MinBytes,
-			MaxBytes: MaxBytes,
-		})
-		c.readers = append(c.readers, r)
-		go runReader(r, consumerConfig, c.logger)
+func newReader(consumerConfig event.ConsumerConfig) *kafka.Reader {
+	startOffset := kafka.FirstOffset
+	if consumerConfig.GetStartOffset() == event.OffsetLast {
+		startOffset = kafka.LastOffset
 	}
 
-	return nil
+	return kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     consumerConfig.GetBrokers(),
+		Topic:       consumerConfig.GetTopic(),
+		GroupID:     consumerConfig.GetGroupID(),
+		StartOffset: startOffset,
+		MinBytes:    MinBytes,
+		MaxBytes:    MaxBytes,
+	})
 }
 
-func (c *consumer) Close() {
-	for _, reader := range c.readers {
-		if err := reader.Close(); err != nil {
-			c.logger.Error("consumer reader close", zap.Error(err))
+// runReader consumes the topic of consumerConfig, a reader failing to fetch
+// is replaced by a new one after a backoff. It returns nil when the
+// consumer stops and the last fetch error when the reader gives up
+func (c *consumer) runReader(consumerConfig event.ConsumerConfig) error {
+	var (
+		topic    = consumerConfig.GetTopic()
+		r        = newReader(consumerConfig)
+		failures int
+	)
+	defer func() {
+		if err := r.Close(); err != nil {
+			c.logger.Error("consumer reader close", zap.String("topic", topic), zap.Error(err))
+		}
+	}()
+
+	for {
+		fetched, err := newPool(c, r, consumerConfig).run()
+		if err == nil {
+			return nil
+		}
+		if fetched > 0 {
+			failures = 0
+		}
+
+		failures++
+		if failures >= readerRestartPolicy.MaxAttempts {
+			c.logger.Error("consumer failed to fetch message, giving up:", zap.String("topic", topic), zap.Int("failures", failures), zap.Error(err))
+			return err
 		}
+		c.logger.Error("consumer failed to fetch message, restarting reader:", zap.String("topic", topic), zap.Int("failures", failures), zap.Error(err))
+
+		if err := r.Close(); err != nil {
+			c.logger.Error("consumer reader close", zap.String("topic", topic), zap.Error(err))
+		}
+		if !messaging.Sleep(c.Fetching(), readerRestartPolicy.Backoff(failures)) {
+			// closing the replaced reader again in defer is a no-op
+			return nil
+		}
+		r = newReader(consumerConfig)
+	}
+}
+
+// observeFetched records offset and lag of the partition of the fetched message
+func observeFetched(consumerConfig event.ConsumerConfig, m kafka.Message) {
+	partition := strconv.Itoa(m.Partition)
+	metrics.ConsumerOffset.WithLabelValues(m.Topic, consumerConfig.GetGroupID(), partition).Set(float64(m.Offset))
+
+	// the high water mark is the offset the next produced message gets
+	lag := m.HighWaterMark - m.Offset - 1
+	if lag < 0 {
+		lag = 0
 	}
+	metrics.ConsumerLag.WithLabelValues(m.Topic, consumerConfig.GetGroupID(), partition).Set(float64(lag))
 }
 
-func runReader(r *kafka.Reader, consumerConfig event.ConsumerConfig, logger *zap.Logger) {
+// observeReader records stats of the reader, counters of the stats are
+// reset on every read so they are added as they are
+func observeReader(consumerConfig event.ConsumerConfig, r *kafka.Reader) {
 	var (
+		stats   = r.Stats()
 		topic   = consumerConfig.GetTopic()
-		handler = consumerConfig.GetHandler()
+		groupID = consumerConfig.GetGroupID()
 	)
-	for {
-		ctx := context.Background()
-		m, err := r.FetchMessage(ctx)
-		if err != nil {
-			logger.Error("consumer failed to fetch message:", zap.String("topic", topic), zap.Error(err))
-			break
-		}
+	metrics.ConsumerReaderLag.WithLabelValues(topic, groupID).Set(float64(stats.Lag))
+	metrics.ConsumerReaderErrors.WithLabelValues(topic, groupID).Add(float64(stats.Errors))
+	metrics.ConsumerReaderRebalances.WithLabelValues(topic, groupID).Add(float64(stats.Rebalances))
+}
 
-		if err := handler(ctx, m.Key, m.Value); err != nil {
-			logger.Error("consumer failed to handle message:", zap.ByteString("value", m.Value), zap.String("topic", topic), zap.Error(err))
-			continue
-		}
+func consumedMessage(m kafka.Message) event.Message {
+	headers := make([]event.Header, 0, len(m.Headers))
+	for _, header := range m.Headers {
+		headers = append(headers, event.Header{Key: header.Key, Value: header.Value})
+	}
 
-		if err := r.CommitMessages(ctx, m); err != nil {
-			logger.Error("consumer failed to commit messages:", zap.String("topic", topic), zap.Error(err))
-		}
+	return event.Message{
+		Topic:     m.Topic,
+		Partition: m.Partition,
+		Offset:    m.Offset,
+		Key:       m.Key,
+		Value:     m.Value,
+		Headers:   headers,
+		Time:      m.Time,
 	}
 }
 
+// NewRetryPolicy reads consumer retry settings from conf, failed messages go to deadLetterTopic
+func NewRetryPolicy(conf *config.Config, deadLetterTopic string) (event.RetryPolicy, error) {
+	maxAttempts, err := strconv.Atoi(conf.Kafka.Consumer.MaxAttempts)
+	if err != nil || maxAttempts <= 0 {
+		return event.RetryPolicy{}, fmt.Errorf("invalid kafka consumer max attempts '%s'", conf.Kafka.Consumer.MaxAttempts)
+	}
+	initialBackoff, err := time.ParseDuration(conf.Kafka.Consumer.InitialBackoff)
+	if err != nil {
+		return event.RetryPolicy{}, fmt.Errorf("error during parse duration for kafka consumer initial backoff : %w", err)
+	}
+	maxBackoff, err := time.ParseDuration(conf.Kafka.Consumer.MaxBackoff)
+	if err != nil {
+		return event.RetryPolicy{}, fmt.Errorf("error during parse duration for kafka consumer max backoff : %w", err)
+	}
+
+	return event.RetryPolicy{
+		MaxAttempts:     maxAttempts,
+		InitialBackoff:  initialBackoff,
+		MaxBackoff:      maxBackoff,
+		DeadLetterTopic: deadLetterTopic,
+	}, nil
+}
+
 type ConsumerConfig struct {
-	brokers []string
-	topic   string
-	groupID string
-	handler HandlerFunc
+	brokers     []string
+	topic       string
+	groupID     string
+	startOffset string
+	handler     HandlerFunc
+	retryPolicy event.RetryPolicy
+	workers     int
 }
 
 func NewConsumerConfig(
 	brokers []string,
 	topic string,
 	groupID string,
+	startOffset string,
 	handler HandlerFunc,
+	retryPolicy event.RetryPolicy,
+	workers int,
 ) *ConsumerConfig {
 	fmt.Println("New consumer config")
 	return &ConsumerConfig{
-		brokers: brokers,
-		topic:   topic,
-		groupID: groupID,
-		handler: handler,
+		brokers:     brokers,
+		topic:       topic,
+		groupID:     groupID,
+		startOffset: startOffset,
+		handler:     handler,
+		retryPolicy: retryPolicy,
+		workers:     workers,
 	}
 }
 
@@ -114,6 +212,18 @@ func (c *ConsumerConfig) GetGroupID() string {
 	return c.groupID
 }
 
-func (c *ConsumerConfig) GetHandler() func(ctx context.Context, key, value []byte) error {
+func (c *ConsumerConfig) GetStartOffset() string {
+	return c.startOffset
+}
+
+func (c *ConsumerConfig) GetHandler() func(ctx context.Context, message event.Message) error {
 	return c.handler
 }
+
+func (c *ConsumerConfig) GetRetryPolicy() event.RetryPolicy {
+	return c.retryPolicy
+}
+
+func (c *ConsumerConfig) GetWorkers() int {
+	return c.workers
+}
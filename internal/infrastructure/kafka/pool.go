@@ -0,0 +1,247 @@
+package kafka
+
+import (
+	"errors"
+	"fourth-exam/user-service-evrone/internal/usecase/event"
+	"hash/fnv"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+// workerQueueSize is how many fetched messages may wait for a busy worker
+const workerQueueSize = 16
+
+// readerStatsInterval is how often reader stats are recorded
+const readerStatsInterval = 10 * time.Second
+
+type task struct {
+	message    kafka.Message
+	generation int
+}
+
+// pool handles messages of one reader on several workers. A message goes to
+// the worker picked by its key, so messages of the same key are handled one
+// after another in fetch order while other keys proceed concurrently
+type pool struct {
+	consumer *consumer
+	reader   *kafka.Reader
+	config   event.ConsumerConfig
+
+	queues  []chan task
+	workers sync.WaitGroup
+	next    int
+
+	offsets   *offsetTracker
+	commits   chan kafka.Message
+	committed chan struct{}
+
+	stopStats chan struct{}
+	statsDone chan struct{}
+}
+
+func newPool(c *consumer, r *kafka.Reader, consumerConfig event.ConsumerConfig) *pool {
+	workers := consumerConfig.GetWorkers()
+	if workers < 1 {
+		workers = 1
+	}
+
+	p := &pool{
+		consumer:  c,
+		reader:    r,
+		config:    consumerConfig,
+		queues:    make([]chan task, workers),
+		offsets:   newOffsetTracker(),
+		commits:   make(chan kafka.Message, workers*workerQueueSize),
+		committed: make(chan struct{}),
+		stopStats: make(chan struct{}),
+		statsDone: make(chan struct{}),
+	}
+	for i := range p.queues {
+		p.queues[i] = make(chan task, workerQueueSize)
+		p.workers.Add(1)
+		go p.work(p.queues[i])
+	}
+	go p.commit()
+	go p.stats()
+
+	return p
+}
+
+// run fetches and dispatches messages until the consumer is closed or fetch
+// fails, it returns the number of fetched messages and the fetch error.
+// Dispatched messages are handled and committed before run returns
+func (p *pool) run() (int, error) {
+	defer p.close()
+
+	var fetched int
+	for {
+		m, err := p.reader.FetchMessage(p.consumer.Fetching())
+		if err != nil {
+			if p.consumer.Fetching().Err() != nil || errors.Is(err, io.EOF) {
+				return fetched, nil
+			}
+			return fetched, err
+		}
+		fetched++
+		observeFetched(p.config, m)
+
+		if !p.dispatch(m) {
+			return fetched, nil
+		}
+	}
+}
+
+// dispatch queues m to its worker and reports false if the consumer stops fetching meanwhile
+func (p *pool) dispatch(m kafka.Message) bool {
+	t := task{message: m, generation: p.offsets.add(m)}
+
+	select {
+	case p.queues[p.worker(m)] <- t:
+		return true
+	case <-p.consumer.Fetching().Done():
+		return false
+	}
+}
+
+// worker picks the queue of m, messages without key have no order to keep
+// and are spread round robin
+func (p *pool) worker(m kafka.Message) int {
+	if len(m.Key) == 0 {
+		p.next = (p.next + 1) % len(p.queues)
+		return p.next
+	}
+
+	h := fnv.New32a()
+	h.Write(m.Key)
+	return int(h.Sum32() % uint32(len(p.queues)))
+}
+
+func (p *pool) work(queue chan task) {
+	defer p.workers.Done()
+
+	for t := range queue {
+		// once fetching stops only handlers already running are waited for,
+		// queued messages stay uncommitted like the ones of a closing handler
+		if p.consumer.Fetching().Err() != nil {
+			continue
+		}
+		if err := p.consumer.Handle(p.config, consumedMessage(t.message)); err != nil {
+			continue
+		}
+		p.offsets.done(t.message, t.generation, p.commits)
+	}
+}
+
+// commit commits offsets in the order the tracker released them, offsets of
+// messages handled before shutdown are committed while the consumer drains
+func (p *pool) commit() {
+	defer close(p.committed)
+
+	for m := range p.commits {
+		if err := p.reader.CommitMessages(p.consumer.Handling(), m); err != nil {
+			p.consumer.logger.Error("consumer failed to commit messages:", zap.String("topic", m.Topic), zap.Int("partition", m.Partition), zap.Int64("offset", m.Offset), zap.Error(err))
+		}
+	}
+}
+
+// stats records reader stats periodically and once more when the pool closes
+func (p *pool) stats() {
+	defer close(p.statsDone)
+
+	ticker := time.NewTicker(readerStatsInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			observeReader(p.config, p.reader)
+		case <-p.stopStats:
+			observeReader(p.config, p.reader)
+			return
+		}
+	}
+}
+
+func (p *pool) close() {
+	for _, queue := range p.queues {
+		close(queue)
+	}
+	p.workers.Wait()
+
+	close(p.commits)
+	<-p.committed
+
+	close(p.stopStats)
+	<-p.statsDone
+}
+
+// offsetTracker releases an offset of a partition for commit only when it
+// and every offset fetched before it in that partition are handled
+type offsetTracker struct {
+	mu         sync.Mutex
+	partitions map[int]*partitionOffsets
+}
+
+type partitionOffsets struct {
+	// generation changes when the partition is fetched again from an
+	// earlier offset after a rebalance, older tasks are ignored then
+	generation int
+	pending    []int64
+	done       map[int64]bool
+}
+
+func newOffsetTracker() *offsetTracker {
+	return &offsetTracker{partitions: make(map[int]*partitionOffsets)}
+}
+
+// add tracks the fetched message and returns its generation
+func (t *offsetTracker) add(m kafka.Message) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	partition, ok := t.partitions[m.Partition]
+	if !ok {
+		partition = &partitionOffsets{done: make(map[int64]bool)}
+		t.partitions[m.Partition] = partition
+	}
+	if n := len(partition.pending); n > 0 && m.Offset <= partition.pending[n-1] {
+		partition.generation++
+		partition.pending = nil
+		partition.done = make(map[int64]bool)
+	}
+
+	partition.pending = append(partition.pending, m.Offset)
+	return partition.generation
+}
+
+// done marks the message handled and sends the highest message that may be
+// committed to commits. Sending under the lock keeps commits of a partition
+// in increasing order
+func (t *offsetTracker) done(m kafka.Message, generation int, commits chan<- kafka.Message) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	partition := t.partitions[m.Partition]
+	if partition == nil || partition.generation != generation {
+		return
+	}
+	partition.done[m.Offset] = true
+
+	watermark := int64(-1)
+	for len(partition.pending) > 0 && partition.done[partition.pending[0]] {
+		watermark = partition.pending[0]
+		delete(partition.done, watermark)
+		partition.pending = partition.pending[1:]
+	}
+	if watermark < 0 {
+		return
+	}
+
+	commit := m
+	commit.Offset = watermark
+	commits <- commit
+}
@@ -0,0 +1,125 @@
+package kafka
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+)
+
+type trackerStep struct {
+	// fetch adds the message to the tracker, otherwise it is marked done with
+	// the generation it was fetched in last
+	fetch     bool
+	partition int
+	offset    int64
+	// commit is the offset the step releases for commit, -1 for none
+	commit int64
+}
+
+func fetched(partition int, offset int64) trackerStep {
+	return trackerStep{fetch: true, partition: partition, offset: offset, commit: -1}
+}
+
+func handled(partition int, offset, commit int64) trackerStep {
+	return trackerStep{partition: partition, offset: offset, commit: commit}
+}
+
+func TestOffsetTracker(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []trackerStep
+	}{
+		{
+			name: "in order",
+			steps: []trackerStep{
+				fetched(0, 0), fetched(0, 1),
+				handled(0, 0, 0), handled(0, 1, 1),
+			},
+		},
+		{
+			name: "out of order commits up to the lowest handled offset",
+			steps: []trackerStep{
+				fetched(0, 0), fetched(0, 1), fetched(0, 2),
+				handled(0, 2, -1),
+				handled(0, 1, -1),
+				handled(0, 0, 2),
+			},
+		},
+		{
+			name: "out of order releases in parts",
+			steps: []trackerStep{
+				fetched(0, 0), fetched(0, 1), fetched(0, 2), fetched(0, 3),
+				handled(0, 1, -1),
+				handled(0, 0, 1),
+				handled(0, 3, -1),
+				handled(0, 2, 3),
+			},
+		},
+		{
+			name: "gaps in offsets",
+			steps: []trackerStep{
+				fetched(0, 10), fetched(0, 13), fetched(0, 20),
+				handled(0, 13, -1),
+				handled(0, 10, 13),
+				handled(0, 20, 20),
+			},
+		},
+		{
+			name: "fetched again from an earlier offset ignores older tasks",
+			steps: []trackerStep{
+				fetched(0, 5), fetched(0, 6), fetched(0, 7),
+				handled(0, 5, 5),
+				fetched(0, 6),
+				// 7 was fetched before the partition was fetched again
+				handled(0, 7, -1),
+				handled(0, 6, 6),
+				fetched(0, 7),
+				handled(0, 7, 7),
+			},
+		},
+		{
+			name: "partitions are tracked apart",
+			steps: []trackerStep{
+				fetched(0, 0), fetched(1, 0), fetched(0, 1),
+				handled(0, 1, -1),
+				handled(1, 0, 0),
+				handled(0, 0, 1),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				tracker     = newOffsetTracker()
+				commits     = make(chan kafka.Message, len(tt.steps))
+				generations = make(map[string]int)
+			)
+			for i, step := range tt.steps {
+				key := fmt.Sprintf("%d/%d", step.partition, step.offset)
+				m := kafka.Message{Topic: "topic", Partition: step.partition, Offset: step.offset}
+
+				if step.fetch {
+					generations[key] = tracker.add(m)
+					continue
+				}
+
+				tracker.done(m, generations[key], commits)
+				select {
+				case commit := <-commits:
+					if step.commit < 0 {
+						t.Fatalf("step %d: handling %s committed offset %d, want no commit", i, key, commit.Offset)
+					}
+					if commit.Partition != step.partition || commit.Offset != step.commit {
+						t.Fatalf("step %d: handling %s committed %d/%d, want %d/%d", i, key, commit.Partition, commit.Offset, step.partition, step.commit)
+					}
+				default:
+					if step.commit >= 0 {
+						t.Fatalf("step %d: handling %s committed nothing, want offset %d", i, key, step.commit)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"fourth-exam/user-service-evrone/internal/infrastructure/messaging"
+	"fourth-exam/user-service-evrone/internal/pkg/config"
+	"fourth-exam/user-service-evrone/internal/pkg/otlp"
+	"fourth-exam/user-service-evrone/internal/usecase/event"
+	"strconv"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+type ProducerConfig struct {
+	Brokers []string
+	// Topic is used for messages that do not name one
+	Topic        string
+	BatchSize    int
+	BatchTimeout time.Duration
+	// Async makes Produce return before messages are acknowledged, failures are only logged
+	Async bool
+}
+
+// NewProducerConfig reads producer settings of topic from conf
+func NewProducerConfig(conf *config.Config, topic string) (*ProducerConfig, error) {
+	batchSize, err := strconv.Atoi(conf.Kafka.Producer.BatchSize)
+	if err != nil || batchSize <= 0 {
+		return nil, fmt.Errorf("invalid kafka producer batch size '%s'", conf.Kafka.Producer.BatchSize)
+	}
+	batchTimeout, err := time.ParseDuration(conf.Kafka.Producer.BatchTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("error during parse duration for kafka producer batch timeout : %w", err)
+	}
+	async, err := strconv.ParseBool(conf.Kafka.Producer.Async)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kafka producer async mode '%s'", conf.Kafka.Producer.Async)
+	}
+
+	return &ProducerConfig{
+		Brokers:      conf.Kafka.Address,
+		Topic:        topic,
+		BatchSize:    batchSize,
+		BatchTimeout: batchTimeout,
+		Async:        async,
+	}, nil
+}
+
+type producer struct {
+	logger *zap.Logger
+	topic  string
+	writer *kafka.Writer
+}
+
+// NewProducer writes messages keyed by their key, so messages with the same
+// key land on one partition in the order they were produced
+func NewProducer(logger *zap.Logger, cfg *ProducerConfig) *producer {
+	p := &producer{
+		logger: logger,
+		topic:  cfg.Topic,
+	}
+	p.writer = &kafka.Writer{
+		Addr:         kafka.TCP(cfg.Brokers...),
+		Balancer:     &kafka.Hash{},
+		BatchSize:    cfg.BatchSize,
+		BatchTimeout: cfg.BatchTimeout,
+		RequiredAcks: kafka.RequireAll,
+		Async:        cfg.Async,
+	}
+	if cfg.Async {
+		p.writer.Completion = p.completion
+	}
+	return p
+}
+
+func (p *producer) Produce(ctx context.Context, messages ...event.Message) error {
+	kafkaMessages := make([]kafka.Message, 0, len(messages))
+	for _, message := range messages {
+		topic := message.Topic
+		if topic == "" {
+			topic = p.topic
+		}
+
+		eventHeaders := append([]event.Header(nil), message.Headers...)
+		// messages relaying an earlier trace, e.g. from the outbox, keep it
+		if carrier := (messaging.HeaderCarrier{Headers: &eventHeaders}); carrier.Get(event.HeaderTraceParent) == "" {
+			otlp.InjectTraceContext(ctx, carrier)
+		}
+
+		headers := make([]kafka.Header, 0, len(eventHeaders))
+		for _, header := range eventHeaders {
+			headers = append(headers, kafka.Header{Key: header.Key, Value: header.Value})
+		}
+
+		kafkaMessages = append(kafkaMessages, kafka.Message{
+			Topic:   topic,
+			Key:     message.Key,
+			Value:   message.Value,
+			Headers: headers,
+			Time:    message.Time,
+		})
+	}
+
+	return p.writer.WriteMessages(ctx, kafkaMessages...)
+}
+
+// completion reports failed batches of an asynchronous producer
+func (p *producer) completion(messages []kafka.Message, err error) {
+	if err == nil || len(messages) == 0 {
+		return
+	}
+	p.logger.Error("producer failed to write messages:", zap.String("topic", messages[0].Topic), zap.Int("count", len(messages)), zap.Error(err))
+}
+
+func (p *producer) Close() error {
+	return p.writer.Close()
+}
@@ -0,0 +1,303 @@
+package memory
+
+import (
+	"fourth-exam/user-service-evrone/internal/usecase/event"
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+// Broker keeps topics in memory with the semantics the service relies on in
+// Kafka: messages with the same key go to one partition in produce order,
+// partitions of a topic are shared by the members of a consumer group and
+// a group resumes a partition from its last committed offset, so messages
+// not committed before a member leaves are delivered again
+type Broker struct {
+	partitions int
+
+	mu     sync.Mutex
+	topics map[string]*topic
+	groups map[string]*group
+	// changed is closed and replaced on every change of the broker state,
+	// waiting members wake up and look again
+	changed chan struct{}
+}
+
+type topic struct {
+	partitions [][]event.Message
+	next       int
+}
+
+type partitionKey struct {
+	topic     string
+	partition int
+}
+
+type group struct {
+	// committed holds the offset a partition is resumed from
+	committed map[partitionKey]int64
+	// members of the group per topic in join order
+	members map[string][]*member
+	// owners are members currently reading a partition, a partition
+	// reassigned by a rebalance is read by its new member once released
+	owners map[partitionKey]*member
+}
+
+type member struct {
+	groupID    string
+	topic      string
+	partitions []int
+	// rebalanced is closed when partitions of the member change
+	rebalanced chan struct{}
+}
+
+// NewBroker creates topics with the given number of partitions on first use
+func NewBroker(partitions int) *Broker {
+	if partitions < 1 {
+		partitions = 1
+	}
+
+	return &Broker{
+		partitions: partitions,
+		topics:     make(map[string]*topic),
+		groups:     make(map[string]*group),
+		changed:    make(chan struct{}),
+	}
+}
+
+// Messages returns messages of topic ordered by partition and offset
+func (b *Broker) Messages(topicName string) []event.Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var messages []event.Message
+	for _, partition := range b.topic(topicName).partitions {
+		for _, m := range partition {
+			messages = append(messages, copyMessage(m))
+		}
+	}
+	return messages
+}
+
+// Committed returns the offset group resumes partition of topic from and
+// false if the group has not committed it yet
+func (b *Broker) Committed(groupID, topicName string, partition int) (int64, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	offset, ok := b.group(groupID).committed[partitionKey{topic: topicName, partition: partition}]
+	return offset, ok
+}
+
+// Lag returns how many messages of topic group has not committed yet
+func (b *Broker) Lag(groupID, topicName string) int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var (
+		t   = b.topic(topicName)
+		g   = b.group(groupID)
+		lag int64
+	)
+	for i, partition := range t.partitions {
+		lag += int64(len(partition)) - g.committed[partitionKey{topic: topicName, partition: i}]
+	}
+	return lag
+}
+
+// append stores m in the partition of its key and returns it with the
+// partition and offset set
+func (b *Broker) append(m event.Message) event.Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t := b.topic(m.Topic)
+	if len(m.Key) == 0 {
+		m.Partition = t.next
+		t.next = (t.next + 1) % len(t.partitions)
+	} else {
+		h := fnv.New32a()
+		h.Write(m.Key)
+		m.Partition = int(h.Sum32() % uint32(len(t.partitions)))
+	}
+	if m.Time.IsZero() {
+		m.Time = time.Now()
+	}
+	m.Offset = int64(len(t.partitions[m.Partition]))
+
+	t.partitions[m.Partition] = append(t.partitions[m.Partition], copyMessage(m))
+	b.notify()
+
+	return m
+}
+
+// fetch returns the message at offset of the partition with the offset the
+// next produced message gets, or false and a channel closed on the next
+// change of the broker when there is no message yet
+func (b *Broker) fetch(topicName string, partition int, offset int64) (event.Message, int64, bool, <-chan struct{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	messages := b.topic(topicName).partitions[partition]
+	if offset >= int64(len(messages)) {
+		return event.Message{}, 0, false, b.changed
+	}
+	return copyMessage(messages[offset]), int64(len(messages)), true, nil
+}
+
+// commit stores the offset the group resumes the partition from
+func (b *Broker) commit(groupID, topicName string, partition int, offset int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.group(groupID).committed[partitionKey{topic: topicName, partition: partition}] = offset
+	b.notify()
+}
+
+// join adds a member reading topic to the group and rebalances partitions
+func (b *Broker) join(groupID, topicName string) *member {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	m := &member{groupID: groupID, topic: topicName, rebalanced: make(chan struct{})}
+	g := b.group(groupID)
+	g.members[topicName] = append(g.members[topicName], m)
+	b.rebalance(g, topicName)
+
+	return m
+}
+
+// leave removes the member from its group and rebalances partitions
+func (b *Broker) leave(m *member) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	g := b.group(m.groupID)
+	members := g.members[m.topic]
+	for i := range members {
+		if members[i] == m {
+			g.members[m.topic] = append(members[:i:i], members[i+1:]...)
+			break
+		}
+	}
+	b.rebalance(g, m.topic)
+}
+
+// assignment returns partitions of the member and a channel closed when they change
+func (b *Broker) assignment(m *member) ([]int, <-chan struct{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return append([]int(nil), m.partitions...), m.rebalanced
+}
+
+// claim makes the member owner of the partition, when another member still
+// reads it claim returns false and a channel closed on the next change
+func (b *Broker) claim(m *member, partition int) (bool, <-chan struct{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	g := b.group(m.groupID)
+	key := partitionKey{topic: m.topic, partition: partition}
+	if owner, ok := g.owners[key]; ok && owner != m {
+		return false, b.changed
+	}
+	g.owners[key] = m
+	return true, nil
+}
+
+// release lets other members of the group claim the partition
+func (b *Broker) release(m *member, partition int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	g := b.group(m.groupID)
+	key := partitionKey{topic: m.topic, partition: partition}
+	if g.owners[key] == m {
+		delete(g.owners, key)
+	}
+	b.notify()
+}
+
+// resume returns the committed offset of the partition, a group without one
+// starts at the first or at the next produced message
+func (b *Broker) resume(groupID, topicName string, partition int, startOffset string) int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if offset, ok := b.group(groupID).committed[partitionKey{topic: topicName, partition: partition}]; ok {
+		return offset
+	}
+	if startOffset == event.OffsetLast {
+		return int64(len(b.topic(topicName).partitions[partition]))
+	}
+	return 0
+}
+
+// rebalance spreads partitions of the topic over members of the group round
+// robin and tells every member its partitions changed
+func (b *Broker) rebalance(g *group, topicName string) {
+	var (
+		t       = b.topic(topicName)
+		members = g.members[topicName]
+	)
+	for _, m := range members {
+		m.partitions = nil
+	}
+	for partition := range t.partitions {
+		if len(members) == 0 {
+			break
+		}
+		m := members[partition%len(members)]
+		m.partitions = append(m.partitions, partition)
+	}
+	for _, m := range members {
+		close(m.rebalanced)
+		m.rebalanced = make(chan struct{})
+	}
+	b.notify()
+}
+
+// topic returns the topic creating it on first use, callers hold b.mu
+func (b *Broker) topic(name string) *topic {
+	t, ok := b.topics[name]
+	if !ok {
+		t = &topic{partitions: make([][]event.Message, b.partitions)}
+		b.topics[name] = t
+	}
+	return t
+}
+
+// group returns the group creating it on first use, callers hold b.mu
+func (b *Broker) group(groupID string) *group {
+	g, ok := b.groups[groupID]
+	if !ok {
+		g = &group{
+			committed: make(map[partitionKey]int64),
+			members:   make(map[string][]*member),
+			owners:    make(map[partitionKey]*member),
+		}
+		b.groups[groupID] = g
+	}
+	return g
+}
+
+// notify wakes up members waiting for a change, callers hold b.mu
+func (b *Broker) notify() {
+	close(b.changed)
+	b.changed = make(chan struct{})
+}
+
+// copyMessage keeps stored messages apart from ones handed to producers and handlers
+func copyMessage(m event.Message) event.Message {
+	m.Key = append([]byte(nil), m.Key...)
+	m.Value = append([]byte(nil), m.Value...)
+
+	headers := make([]event.Header, 0, len(m.Headers))
+	for _, header := range m.Headers {
+		headers = append(headers, event.Header{Key: header.Key, Value: append([]byte(nil), header.Value...)})
+	}
+	m.Headers = headers
+
+	return m
+}
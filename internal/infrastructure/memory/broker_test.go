@@ -0,0 +1,125 @@
+package memory
+
+import (
+	"context"
+	"fourth-exam/user-service-evrone/internal/usecase/event"
+	"reflect"
+	"testing"
+)
+
+func closed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestBrokerRebalance(t *testing.T) {
+	broker := NewBroker(4)
+
+	first := broker.join("group", "topic")
+	partitions, rebalanced := broker.assignment(first)
+	if want := []int{0, 1, 2, 3}; !reflect.DeepEqual(partitions, want) {
+		t.Fatalf("first member partitions = %v, want %v", partitions, want)
+	}
+
+	second := broker.join("group", "topic")
+	if !closed(rebalanced) {
+		t.Fatal("first member was not told about the rebalance")
+	}
+	partitions, rebalanced = broker.assignment(first)
+	if want := []int{0, 2}; !reflect.DeepEqual(partitions, want) {
+		t.Fatalf("first member partitions = %v, want %v", partitions, want)
+	}
+	if partitions, _ := broker.assignment(second); !reflect.DeepEqual(partitions, []int{1, 3}) {
+		t.Fatalf("second member partitions = %v, want %v", partitions, []int{1, 3})
+	}
+
+	other := broker.join("other", "topic")
+	if closed(rebalanced) {
+		t.Fatal("member of another group rebalanced the group")
+	}
+	if partitions, _ := broker.assignment(other); len(partitions) != 4 {
+		t.Fatalf("other group member partitions = %v, want all", partitions)
+	}
+
+	broker.leave(second)
+	if !closed(rebalanced) {
+		t.Fatal("first member was not told about the rebalance")
+	}
+	if partitions, _ := broker.assignment(first); len(partitions) != 4 {
+		t.Fatalf("first member partitions = %v, want all", partitions)
+	}
+}
+
+func TestBrokerClaimRelease(t *testing.T) {
+	var (
+		broker = NewBroker(1)
+		first  = broker.join("group", "topic")
+		second = broker.join("group", "topic")
+	)
+
+	if claimed, _ := broker.claim(first, 0); !claimed {
+		t.Fatal("first member could not claim a free partition")
+	}
+	if claimed, _ := broker.claim(first, 0); !claimed {
+		t.Fatal("owner could not claim its partition again")
+	}
+
+	claimed, changed := broker.claim(second, 0)
+	if claimed {
+		t.Fatal("second member claimed a partition owned by the first")
+	}
+
+	broker.release(second, 0)
+	if claimed, _ := broker.claim(second, 0); claimed {
+		t.Fatal("a member that does not own the partition released it")
+	}
+
+	broker.release(first, 0)
+	if !closed(changed) {
+		t.Fatal("waiting member was not woken up by the release")
+	}
+	if claimed, _ := broker.claim(second, 0); !claimed {
+		t.Fatal("second member could not claim the released partition")
+	}
+}
+
+func TestBrokerResume(t *testing.T) {
+	broker := NewBroker(1)
+	producer := NewProducer(broker, "topic")
+	for i := 0; i < 3; i++ {
+		if err := producer.Produce(context.Background(), event.Message{Value: []byte("value")}); err != nil {
+			t.Fatalf("produce: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		commit      int64
+		startOffset string
+		want        int64
+	}{
+		{name: "first without commit", commit: -1, startOffset: event.OffsetFirst, want: 0},
+		{name: "last without commit", commit: -1, startOffset: event.OffsetLast, want: 3},
+		{name: "committed with first", commit: 2, startOffset: event.OffsetFirst, want: 2},
+		{name: "committed with last", commit: 1, startOffset: event.OffsetLast, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.commit >= 0 {
+				broker.commit(tt.name, "topic", 0, tt.commit)
+			}
+			if got := broker.resume(tt.name, "topic", 0, tt.startOffset); got != tt.want {
+				t.Fatalf("resume() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	if lag := broker.Lag("committed with first", "topic"); lag != 1 {
+		t.Fatalf("Lag() = %d, want 1", lag)
+	}
+}
@@ -0,0 +1,121 @@
+package memory
+
+import (
+	"context"
+	"fourth-exam/user-service-evrone/internal/infrastructure/messaging"
+	"fourth-exam/user-service-evrone/internal/pkg/metrics"
+	"fourth-exam/user-service-evrone/internal/usecase/event"
+	"strconv"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// consumer reads registered topics of a Broker as a member of their groups.
+// Partitions are read one message at a time, so messages of a key are
+// handled in order and GetWorkers of a config is not used
+type consumer struct {
+	*messaging.Dispatcher
+	broker *Broker
+}
+
+// NewConsumer reads messages of broker and uses producer to write messages to dead-letter topics
+func NewConsumer(logger *zap.Logger, broker *Broker, producer event.BrokerProducer) *consumer {
+	return &consumer{
+		Dispatcher: messaging.NewDispatcher("memory", logger, producer),
+		broker:     broker,
+	}
+}
+
+// Run consumes all registered topics until ctx is done or Close is called
+// and returns once every member has left its group
+func (c *consumer) Run(ctx context.Context) error {
+	return c.RunReaders(ctx, func(consumerConfig event.ConsumerConfig) error {
+		c.runMember(consumerConfig)
+		return nil
+	})
+}
+
+// runMember joins the group of consumerConfig and reads the partitions
+// assigned to it, after every rebalance the partitions are read again from
+// the committed offsets
+func (c *consumer) runMember(consumerConfig event.ConsumerConfig) {
+	m := c.broker.join(consumerConfig.GetGroupID(), consumerConfig.GetTopic())
+	defer c.broker.leave(m)
+
+	for {
+		partitions, rebalanced := c.broker.assignment(m)
+
+		var wg sync.WaitGroup
+		for _, partition := range partitions {
+			wg.Add(1)
+			go func(partition int) {
+				defer wg.Done()
+				c.runPartition(consumerConfig, m, partition, rebalanced)
+			}(partition)
+		}
+		wg.Wait()
+
+		if c.Fetching().Err() != nil {
+			return
+		}
+		select {
+		case <-rebalanced:
+		case <-c.Fetching().Done():
+			return
+		}
+	}
+}
+
+// runPartition handles messages of the partition from the committed offset
+// until the partition is rebalanced or the consumer stops fetching
+func (c *consumer) runPartition(consumerConfig event.ConsumerConfig, m *member, partition int, rebalanced <-chan struct{}) {
+	// the previous member of the partition finishes its running handler first
+	for {
+		claimed, changed := c.broker.claim(m, partition)
+		if claimed {
+			break
+		}
+		select {
+		case <-changed:
+		case <-rebalanced:
+			return
+		case <-c.Fetching().Done():
+			return
+		}
+	}
+	defer c.broker.release(m, partition)
+
+	var (
+		groupID = consumerConfig.GetGroupID()
+		topic   = consumerConfig.GetTopic()
+		offset  = c.broker.resume(groupID, topic, partition, consumerConfig.GetStartOffset())
+	)
+	for {
+		select {
+		case <-rebalanced:
+			return
+		case <-c.Fetching().Done():
+			return
+		default:
+		}
+
+		message, highWaterMark, ok, changed := c.broker.fetch(topic, partition, offset)
+		if !ok {
+			select {
+			case <-changed:
+			case <-rebalanced:
+			case <-c.Fetching().Done():
+			}
+			continue
+		}
+		metrics.ConsumerOffset.WithLabelValues(topic, groupID, strconv.Itoa(partition)).Set(float64(offset))
+		metrics.ConsumerLag.WithLabelValues(topic, groupID, strconv.Itoa(partition)).Set(float64(highWaterMark - offset - 1))
+
+		if err := c.Handle(consumerConfig, message); err != nil {
+			return
+		}
+		offset++
+		c.broker.commit(groupID, topic, partition, offset)
+	}
+}
@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fourth-exam/user-service-evrone/internal/infrastructure/messaging"
+	"fourth-exam/user-service-evrone/internal/pkg/otlp"
+	"fourth-exam/user-service-evrone/internal/usecase/event"
+	"sync"
+)
+
+var errProducerClosed = errors.New("producer is closed")
+
+type producer struct {
+	broker *Broker
+	topic  string
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewProducer writes messages to broker, messages that do not name a topic go to topic.
+// Produce returns once messages are stored, like a synchronous Kafka producer
+func NewProducer(broker *Broker, topic string) *producer {
+	return &producer{broker: broker, topic: topic}
+}
+
+func (p *producer) Produce(ctx context.Context, messages ...event.Message) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return errProducerClosed
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	for _, message := range messages {
+		if message.Topic == "" {
+			message.Topic = p.topic
+		}
+
+		message.Headers = append([]event.Header(nil), message.Headers...)
+		// messages relaying an earlier trace, e.g. from the outbox, keep it
+		if carrier := (messaging.HeaderCarrier{Headers: &message.Headers}); carrier.Get(event.HeaderTraceParent) == "" {
+			otlp.InjectTraceContext(ctx, carrier)
+		}
+
+		p.broker.append(message)
+	}
+	return nil
+}
+
+func (p *producer) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed = true
+	return nil
+}
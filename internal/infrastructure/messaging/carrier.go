@@ -0,0 +1,35 @@
+package messaging
+
+import "fourth-exam/user-service-evrone/internal/usecase/event"
+
+// HeaderCarrier lets trace context be read from and written to message headers
+type HeaderCarrier struct {
+	Headers *[]event.Header
+}
+
+func (c HeaderCarrier) Get(key string) string {
+	for _, header := range *c.Headers {
+		if header.Key == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+func (c HeaderCarrier) Set(key, value string) {
+	for i, header := range *c.Headers {
+		if header.Key == key {
+			(*c.Headers)[i].Value = []byte(value)
+			return
+		}
+	}
+	*c.Headers = append(*c.Headers, event.Header{Key: key, Value: []byte(value)})
+}
+
+func (c HeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(*c.Headers))
+	for _, header := range *c.Headers {
+		keys = append(keys, header.Key)
+	}
+	return keys
+}
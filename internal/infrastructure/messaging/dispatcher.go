@@ -0,0 +1,246 @@
+// Package messaging holds the consumer runtime shared by the broker drivers
+// in kafka and memory, usecase/event only describes their contracts
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fourth-exam/user-service-evrone/internal/pkg/metrics"
+	"fourth-exam/user-service-evrone/internal/pkg/otlp"
+	"fourth-exam/user-service-evrone/internal/usecase/event"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+const consumerSpanPrefix = "consume "
+
+// deadLetterPolicy spaces out attempts to write a message to its dead-letter topic
+var deadLetterPolicy = event.RetryPolicy{InitialBackoff: time.Second, MaxBackoff: time.Minute}
+
+// ErrClosing is returned by Handle when the consumer stops before the message
+// is handled or dead-lettered, the message must not be committed then
+var ErrClosing = errors.New("consumer is closing")
+
+// Dispatcher is the part of a BrokerConsumer that does not depend on the
+// broker: registered configs, the Run and Close lifecycle and handling of
+// fetched messages with retries, dead-lettering, tracing and metrics.
+// Broker consumers embed it and read messages in RunReaders
+type Dispatcher struct {
+	system          string
+	logger          *zap.Logger
+	producer        event.BrokerProducer
+	consumerConfigs []event.ConsumerConfig
+
+	// fetchCtx is canceled to stop fetching, handleCtx to abort running
+	// handlers once the shutdown deadline is over
+	fetchCtx      context.Context
+	stopFetching  context.CancelFunc
+	handleCtx     context.Context
+	abortHandling context.CancelFunc
+
+	mu      sync.Mutex
+	started bool
+	done    chan struct{}
+}
+
+// NewDispatcher uses producer to write messages to dead-letter topics,
+// system names the broker in spans, e.g. kafka
+func NewDispatcher(system string, logger *zap.Logger, producer event.BrokerProducer) *Dispatcher {
+	d := &Dispatcher{
+		system:   system,
+		logger:   logger,
+		producer: producer,
+		done:     make(chan struct{}),
+	}
+	d.fetchCtx, d.stopFetching = context.WithCancel(context.Background())
+	d.handleCtx, d.abortHandling = context.WithCancel(context.Background())
+	return d
+}
+
+func (d *Dispatcher) RegisterConsumer(consumerConfig event.ConsumerConfig) {
+	d.consumerConfigs = append(d.consumerConfigs, consumerConfig)
+}
+
+// Fetching is done once the consumer stops fetching
+func (d *Dispatcher) Fetching() context.Context {
+	return d.fetchCtx
+}
+
+// Handling is done once running handlers are aborted
+func (d *Dispatcher) Handling() context.Context {
+	return d.handleCtx
+}
+
+// RunReaders runs read for every registered config until ctx is done or
+// Close is called and returns once every read has returned. A read giving up
+// on its own stops the others too, their fetched messages are drained as on
+// Close, so a process never keeps running with some consumers dead; the
+// errors of reads that gave up are returned
+func (d *Dispatcher) RunReaders(ctx context.Context, read func(consumerConfig event.ConsumerConfig) error) error {
+	d.mu.Lock()
+	if d.started {
+		d.mu.Unlock()
+		return errors.New("consumer is already running")
+	}
+	d.started = true
+	d.mu.Unlock()
+	defer close(d.done)
+
+	stop := context.AfterFunc(ctx, d.stopFetching)
+	defer stop()
+
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, len(d.consumerConfigs))
+	)
+	for i, consumerConfig := range d.consumerConfigs {
+		wg.Add(1)
+		go func(i int, consumerConfig event.ConsumerConfig) {
+			defer wg.Done()
+			if err := read(consumerConfig); err != nil {
+				errs[i] = fmt.Errorf("consumer of topic %s: %w", consumerConfig.GetTopic(), err)
+				d.stopFetching()
+			}
+		}(i, consumerConfig)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// Close stops fetching and waits for handlers of fetched messages, their
+// offsets are committed. Handlers still running when ctx is done are
+// canceled, their messages stay uncommitted and are delivered again later
+func (d *Dispatcher) Close(ctx context.Context) error {
+	d.stopFetching()
+
+	d.mu.Lock()
+	started := d.started
+	d.mu.Unlock()
+	if !started {
+		return nil
+	}
+
+	select {
+	case <-d.done:
+		return nil
+	case <-ctx.Done():
+	}
+
+	d.abortHandling()
+	<-d.done
+	return fmt.Errorf("consumer handlers aborted: %w", ctx.Err())
+}
+
+// Handle runs the handler until it succeeds or the retry policy gives up,
+// in which case the message is dead-lettered. The only error it returns is
+// ErrClosing: a closing consumer lets running handlers finish but does not
+// wait for retries. The handler runs under a span continuing the trace
+// found in message headers
+func (d *Dispatcher) Handle(consumerConfig event.ConsumerConfig, message event.Message) error {
+	ctx := otlp.ExtractTraceContext(d.handleCtx, HeaderCarrier{Headers: &message.Headers})
+	ctx, span := otlp.Start(ctx, d.system+"Consumer", consumerSpanPrefix+message.Topic)
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("messaging.system", d.system),
+		attribute.String("messaging.destination", message.Topic),
+		attribute.Int("messaging."+d.system+".partition", message.Partition),
+		attribute.Int64("messaging."+d.system+".offset", message.Offset),
+	)
+
+	var (
+		handler = consumerConfig.GetHandler()
+		policy  = consumerConfig.GetRetryPolicy()
+		groupID = consumerConfig.GetGroupID()
+		attempt int
+		err     error
+	)
+	for attempt = 1; ; attempt++ {
+		start := time.Now()
+		err = handler(ctx, message)
+		metrics.ConsumerHandlerDuration.WithLabelValues(message.Topic, groupID).Observe(time.Since(start).Seconds())
+		if err == nil {
+			metrics.ConsumerMessagesProcessed.WithLabelValues(message.Topic, groupID).Inc()
+			return nil
+		}
+		span.RecordError(err)
+		if d.handleCtx.Err() != nil {
+			return ErrClosing
+		}
+		if attempt >= policy.MaxAttempts || event.IsPermanent(err) {
+			break
+		}
+		metrics.ConsumerMessagesRetried.WithLabelValues(message.Topic, groupID).Inc()
+
+		backoff := policy.Backoff(attempt)
+		d.logger.Warn("consumer failed to handle message, retrying:", zap.String("topic", message.Topic), zap.Int("attempt", attempt), zap.Duration("backoff", backoff), zap.Error(err))
+		if !Sleep(d.fetchCtx, backoff) {
+			return ErrClosing
+		}
+	}
+
+	span.Error(err)
+	metrics.ConsumerMessagesFailed.WithLabelValues(message.Topic, groupID).Inc()
+	if err := d.deadLetter(ctx, policy, message, attempt, err); err != nil {
+		return err
+	}
+	if policy.DeadLetterTopic != "" {
+		metrics.ConsumerMessagesDeadLettered.WithLabelValues(message.Topic, groupID).Inc()
+	}
+	return nil
+}
+
+// deadLetter writes the original message with the failure to the dead-letter
+// topic, retrying until it succeeds so failed messages are never lost
+func (d *Dispatcher) deadLetter(ctx context.Context, policy event.RetryPolicy, m event.Message, attempts int, handleErr error) error {
+	if policy.DeadLetterTopic == "" {
+		d.logger.Error("consumer failed to handle message, skipping:", zap.ByteString("value", m.Value), zap.String("topic", m.Topic), zap.Int("attempts", attempts), zap.Error(handleErr))
+		return nil
+	}
+
+	headers := append(append([]event.Header(nil), m.Headers...),
+		event.Header{Key: event.HeaderOriginalTopic, Value: []byte(m.Topic)},
+		event.Header{Key: event.HeaderOriginalPartition, Value: []byte(strconv.Itoa(m.Partition))},
+		event.Header{Key: event.HeaderOriginalOffset, Value: []byte(strconv.FormatInt(m.Offset, 10))},
+		event.Header{Key: event.HeaderError, Value: []byte(handleErr.Error())},
+		event.Header{Key: event.HeaderAttempts, Value: []byte(strconv.Itoa(attempts))},
+	)
+	message := event.Message{
+		Topic:   policy.DeadLetterTopic,
+		Key:     m.Key,
+		Value:   m.Value,
+		Headers: headers,
+	}
+
+	for failures := 1; ; failures++ {
+		err := d.producer.Produce(ctx, message)
+		if err == nil {
+			d.logger.Error("consumer failed to handle message, dead-lettered:", zap.String("topic", m.Topic), zap.String("dead_letter_topic", policy.DeadLetterTopic), zap.Int("attempts", attempts), zap.Error(handleErr))
+			return nil
+		}
+
+		d.logger.Error("consumer failed to dead-letter message:", zap.String("dead_letter_topic", policy.DeadLetterTopic), zap.Error(err))
+		if !Sleep(d.fetchCtx, deadLetterPolicy.Backoff(failures)) {
+			return ErrClosing
+		}
+	}
+}
+
+// Sleep waits for d and reports false if ctx is done first
+func Sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,54 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fourth-exam/user-service-evrone/internal/usecase/event"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type testConfig struct {
+	topic string
+}
+
+func (c testConfig) GetBrokers() []string              { return nil }
+func (c testConfig) GetTopic() string                  { return c.topic }
+func (c testConfig) GetGroupID() string                { return "group" }
+func (c testConfig) GetStartOffset() string            { return event.OffsetFirst }
+func (c testConfig) GetRetryPolicy() event.RetryPolicy { return event.RetryPolicy{MaxAttempts: 1} }
+func (c testConfig) GetWorkers() int                   { return 1 }
+func (c testConfig) GetHandler() func(ctx context.Context, message event.Message) error {
+	return func(ctx context.Context, message event.Message) error { return nil }
+}
+
+func TestRunReadersStopsWhenReaderGivesUp(t *testing.T) {
+	var (
+		d       = NewDispatcher("test", zap.NewNop(), nil)
+		errDead = errors.New("reader gave up")
+	)
+	d.RegisterConsumer(testConfig{topic: "dead"})
+	d.RegisterConsumer(testConfig{topic: "alive"})
+
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- d.RunReaders(context.Background(), func(consumerConfig event.ConsumerConfig) error {
+			if consumerConfig.GetTopic() == "dead" {
+				return errDead
+			}
+			<-d.Fetching().Done()
+			return nil
+		})
+	}()
+
+	select {
+	case err := <-runErr:
+		if !errors.Is(err, errDead) {
+			t.Fatalf("RunReaders() error = %v, want %v", err, errDead)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("RunReaders kept running after a reader gave up")
+	}
+}
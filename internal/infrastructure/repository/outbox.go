@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"fourth-exam/user-service-evrone/internal/entity"
+	"time"
+)
+
+type Outbox interface {
+	Add(ctx context.Context, event *entity.OutboxEvent) error
+	// TryLock makes the caller's transaction the only relay, it is released on commit or rollback
+	TryLock(ctx context.Context) (bool, error)
+	// FetchPending locks up to limit unsent events in the order they were added
+	FetchPending(ctx context.Context, limit uint64) ([]*entity.OutboxEvent, error)
+	MarkSent(ctx context.Context, ids []int64, sentAt time.Time) error
+	// DeleteSentBefore deletes events sent before sentBefore and returns how many were deleted
+	DeleteSentBefore(ctx context.Context, sentBefore time.Time) (int64, error)
+}
@@ -0,0 +1,153 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"fourth-exam/user-service-evrone/internal/entity"
+	"fourth-exam/user-service-evrone/internal/pkg/otlp"
+	"fourth-exam/user-service-evrone/internal/pkg/postgres"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	outboxTableName      = "outbox"
+	outboxSpanRepoPrefix = "outboxRepo"
+	// outboxRelayLockKey is the advisory lock held by the active relay
+	outboxRelayLockKey = 7310021
+)
+
+type outboxRepo struct {
+	tableName string
+	db        *postgres.PostgresDB
+}
+
+func NewOutboxRepo(db *postgres.PostgresDB) *outboxRepo {
+	return &outboxRepo{
+		tableName: outboxTableName,
+		db:        db,
+	}
+}
+
+func (o *outboxRepo) Add(ctx context.Context, event *entity.OutboxEvent) error {
+	ctx, span := otlp.Start(ctx, userServiceName, outboxSpanRepoPrefix+"Add")
+	defer span.End()
+
+	span.SetAttributes(attribute.KeyValue{Key: "Outbox -> repository -> ", Value: attribute.StringValue(event.EventType)})
+
+	query, args, err := o.db.Sq.Builder.Insert(o.tableName).SetMap(map[string]any{
+		"event_id":      event.EventID,
+		"aggregate_id":  event.AggregateID,
+		"event_type":    event.EventType,
+		"payload":       event.Payload,
+		"trace_context": event.TraceContext,
+		"created_at":    event.CreatedAt,
+	}).ToSql()
+	if err != nil {
+		return o.db.ErrSQLBuild(err, o.tableName+" add")
+	}
+
+	if _, err = o.db.Conn(ctx).Exec(ctx, query, args...); err != nil {
+		return o.db.Error(err)
+	}
+
+	return nil
+}
+
+func (o *outboxRepo) TryLock(ctx context.Context) (bool, error) {
+	var locked bool
+	if err := o.db.Conn(ctx).QueryRow(ctx, "SELECT pg_try_advisory_xact_lock($1)", outboxRelayLockKey).Scan(&locked); err != nil {
+		return false, o.db.Error(err)
+	}
+	return locked, nil
+}
+
+func (o *outboxRepo) FetchPending(ctx context.Context, limit uint64) ([]*entity.OutboxEvent, error) {
+	ctx, span := otlp.Start(ctx, userServiceName, outboxSpanRepoPrefix+"FetchPending")
+	defer span.End()
+
+	query, args, err := o.db.Sq.Builder.
+		Select("id", "event_id", "aggregate_id", "event_type", "payload", "trace_context", "created_at").
+		From(o.tableName).
+		Where(o.db.Sq.Equal("sent_at", nil)).
+		OrderBy("id").
+		Limit(limit).
+		Suffix("FOR UPDATE SKIP LOCKED").
+		ToSql()
+	if err != nil {
+		return nil, o.db.ErrSQLBuild(err, o.tableName+" fetch pending")
+	}
+
+	rows, err := o.db.Conn(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, o.db.Error(err)
+	}
+	defer rows.Close()
+
+	var events []*entity.OutboxEvent
+	for rows.Next() {
+		var event entity.OutboxEvent
+		if err = rows.Scan(
+			&event.ID,
+			&event.EventID,
+			&event.AggregateID,
+			&event.EventType,
+			&event.Payload,
+			&event.TraceContext,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, o.db.Error(err)
+		}
+		events = append(events, &event)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, o.db.Error(err)
+	}
+
+	return events, nil
+}
+
+func (o *outboxRepo) MarkSent(ctx context.Context, ids []int64, sentAt time.Time) error {
+	ctx, span := otlp.Start(ctx, userServiceName, outboxSpanRepoPrefix+"MarkSent")
+	defer span.End()
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query, args, err := o.db.Sq.Builder.
+		Update(o.tableName).
+		Set("sent_at", sentAt).
+		Where(o.db.Sq.Equal("id", ids)).
+		ToSql()
+	if err != nil {
+		return o.db.ErrSQLBuild(err, fmt.Sprintf("%s %s", o.tableName, "mark sent"))
+	}
+
+	if _, err = o.db.Conn(ctx).Exec(ctx, query, args...); err != nil {
+		return o.db.Error(err)
+	}
+
+	return nil
+}
+
+func (o *outboxRepo) DeleteSentBefore(ctx context.Context, sentBefore time.Time) (int64, error) {
+	ctx, span := otlp.Start(ctx, userServiceName, outboxSpanRepoPrefix+"DeleteSentBefore")
+	defer span.End()
+
+	query, args, err := o.db.Sq.Builder.
+		Delete(o.tableName).
+		Where(o.db.Sq.Lt("sent_at", sentBefore)).
+		ToSql()
+	if err != nil {
+		return 0, o.db.ErrSQLBuild(err, fmt.Sprintf("%s %s", o.tableName, "delete sent before"))
+	}
+
+	commandTag, err := o.db.Conn(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return 0, o.db.Error(err)
+	}
+
+	return commandTag.RowsAffected(), nil
+}
@@ -0,0 +1,72 @@
+package postgresql
+
+import (
+	"context"
+	"fourth-exam/user-service-evrone/internal/pkg/otlp"
+	"fourth-exam/user-service-evrone/internal/pkg/postgres"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	processedMessagesTableName      = "processed_messages"
+	processedMessagesSpanRepoPrefix = "processedMessagesRepo"
+)
+
+type processedMessageRepo struct {
+	tableName string
+	db        *postgres.PostgresDB
+}
+
+func NewProcessedMessageRepo(db *postgres.PostgresDB) *processedMessageRepo {
+	return &processedMessageRepo{
+		tableName: processedMessagesTableName,
+		db:        db,
+	}
+}
+
+func (p *processedMessageRepo) Add(ctx context.Context, consumer, messageID string, processedAt time.Time) (bool, error) {
+	ctx, span := otlp.Start(ctx, userServiceName, processedMessagesSpanRepoPrefix+"Add")
+	defer span.End()
+
+	span.SetAttributes(attribute.KeyValue{Key: "ProcessedMessage -> repository -> ", Value: attribute.StringValue(consumer)})
+
+	query, args, err := p.db.Sq.Builder.Insert(p.tableName).SetMap(map[string]any{
+		"consumer":     consumer,
+		"message_id":   messageID,
+		"processed_at": processedAt,
+	}).Suffix("ON CONFLICT (consumer, message_id) DO NOTHING").ToSql()
+	if err != nil {
+		return false, p.db.ErrSQLBuild(err, p.tableName+" add")
+	}
+
+	commandTag, err := p.db.Conn(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return false, p.db.Error(err)
+	}
+
+	return commandTag.RowsAffected() == 1, nil
+}
+
+func (p *processedMessageRepo) DeleteBefore(ctx context.Context, processedBefore time.Time) (int64, error) {
+	ctx, span := otlp.Start(ctx, userServiceName, processedMessagesSpanRepoPrefix+"DeleteBefore")
+	defer span.End()
+
+	span.SetAttributes(attribute.KeyValue{Key: "ProcessedMessage -> repository -> ", Value: attribute.StringValue("Delete processed messages")})
+
+	query, args, err := p.db.Sq.Builder.
+		Delete(p.tableName).
+		Where(p.db.Sq.Lt("processed_at", processedBefore)).
+		ToSql()
+	if err != nil {
+		return 0, p.db.ErrSQLBuild(err, p.tableName+" delete before")
+	}
+
+	commandTag, err := p.db.Conn(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return 0, p.db.Error(err)
+	}
+
+	return commandTag.RowsAffected(), nil
+}
@@ -3,12 +3,18 @@ package postgresql
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"fourth-exam/user-service-evrone/internal/entity"
 	"fourth-exam/user-service-evrone/internal/pkg/otlp"
+	"fourth-exam/user-service-evrone/internal/pkg/password"
 	"fourth-exam/user-service-evrone/internal/pkg/postgres"
+	"strings"
+	"time"
 
 	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
 	"go.opentelemetry.io/otel/attribute"
 )
 
@@ -18,15 +24,51 @@ const (
 	userSpanRepoPrefix = "userServiceRepo"
 )
 
+// checkableColumns maps the fields accepted by CheckField to their columns
+var checkableColumns = map[string]string{
+	"username": "username",
+	"email":    "email",
+}
+
+// filterableColumns lists the params keys Get is allowed to filter by and
+// whether the comparison ignores case
+var filterableColumns = map[string]bool{
+	"id":       false,
+	"username": true,
+	"email":    true,
+}
+
+// updatableColumns maps the fields Update may write to their values
+var updatableColumns = map[string]func(*entity.User) any{
+	"username":   func(user *entity.User) any { return user.Username },
+	"email":      func(user *entity.User) any { return user.Email },
+	"first_name": func(user *entity.User) any { return user.FirstName },
+	"last_name":  func(user *entity.User) any { return user.LastName },
+	"bio":        func(user *entity.User) any { return user.Bio },
+	"website":    func(user *entity.User) any { return user.Website },
+	"is_active":  func(user *entity.User) any { return user.IsActive },
+}
+
+// uniqueIndexFields maps unique indexes of users to the fields they guard
+var uniqueIndexFields = map[string]string{
+	"users_email_unique_idx":    "email",
+	"users_username_unique_idx": "username",
+}
+
 type userRepo struct {
 	tableName string
 	db        *postgres.PostgresDB
+	hasher    *password.Hasher
 }
 
-func NewUsersRepo(db *postgres.PostgresDB) *userRepo {
+// NewUsersRepo keeps password hashes inside the repository: they are written
+// as hashed by the usecase, checked and rehashed through hasher and never
+// selected into entity.User
+func NewUsersRepo(db *postgres.PostgresDB, hasher *password.Hasher) *userRepo {
 	return &userRepo{
 		tableName: usersTableName,
 		db:        db,
+		hasher:    hasher,
 	}
 }
 
@@ -35,7 +77,6 @@ func (u *userRepo) usersSelectQueryPrefix() squirrel.SelectBuilder {
 		"id",
 		"username",
 		"email",
-		"password",
 		"first_name",
 		"last_name",
 		"bio",
@@ -44,10 +85,103 @@ func (u *userRepo) usersSelectQueryPrefix() squirrel.SelectBuilder {
 		"refresh_token",
 		"created_at",
 		"updated_at",
+		"deleted_at",
+		"version",
 	).From(u.tableName)
 }
 
-func (u *userRepo) Create(ctx context.Context, req *entity.User) (*entity.User, error) {
+// scanUser scans a row selected with usersSelectQueryPrefix, extra
+// destinations are filled with the columns appended after the prefix
+func (u *userRepo) scanUser(row pgx.Row, extra ...any) (*entity.User, error) {
+	var (
+		user      entity.User
+		updatedAt sql.NullTime
+		deletedAt sql.NullTime
+	)
+	dest := []any{
+		&user.Id,
+		&user.Username,
+		&user.Email,
+		&user.FirstName,
+		&user.LastName,
+		&user.Bio,
+		&user.Website,
+		&user.IsActive,
+		&user.RefreshToken,
+		&user.CreatedAt,
+		&updatedAt,
+		&deletedAt,
+		&user.Version,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	if updatedAt.Valid {
+		user.UpdatedAt = updatedAt.Time
+	}
+	if deletedAt.Valid {
+		user.DeletedAt = deletedAt.Time
+	}
+	return &user, nil
+}
+
+// versionIs matches rows of the expected version, 0 matches any version
+func (u *userRepo) versionIs(version int64) squirrel.Sqlizer {
+	if version == 0 {
+		return u.db.Sq.And()
+	}
+	return u.db.Sq.Equal("version", version)
+}
+
+// notUpdated explains why a conditional write of user id changed no rows:
+// the user is gone or, when version was expected, someone changed it first
+func (u *userRepo) notUpdated(ctx context.Context, id string, version int64) error {
+	if version == 0 {
+		return entity.NewErrNotFound("user")
+	}
+
+	query, args, err := u.db.Sq.Builder.
+		Select("version").
+		From(u.tableName).
+		Where(u.db.Sq.Equal("id", id)).
+		Where(u.notDeleted()).
+		ToSql()
+	if err != nil {
+		return u.db.ErrSQLBuild(err, u.tableName+" version")
+	}
+
+	var current int64
+	if err = u.db.Conn(ctx).QueryRow(ctx, query, args...).Scan(&current); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.NewErrNotFound("user")
+		}
+		return u.db.Error(err)
+	}
+
+	return entity.NewErrVersionMismatch("user")
+}
+
+// error is db.Error which also tells which identity collided on unique violation
+func (u *userRepo) error(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		if field, ok := uniqueIndexFields[pgErr.ConstraintName]; ok {
+			return entity.NewErrFieldConflict("user", field)
+		}
+	}
+	return u.db.Error(err)
+}
+
+// likeEscaper escapes LIKE wildcards so user input is matched literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// notDeleted filters out soft deleted users
+func (u *userRepo) notDeleted() squirrel.Sqlizer {
+	return squirrel.Eq{"deleted_at": nil}
+}
+
+func (u *userRepo) Create(ctx context.Context, req *entity.User, passwordHash string) (*entity.User, error) {
 	ctx, span := otlp.Start(ctx, userServiceName, userSpanRepoPrefix+"Create")
 	defer span.End()
 
@@ -57,7 +191,7 @@ func (u *userRepo) Create(ctx context.Context, req *entity.User) (*entity.User,
 		"id":            req.Id,
 		"username":      req.Username,
 		"email":         req.Email,
-		"password":      req.Password,
+		"password":      passwordHash,
 		"first_name":    req.FirstName,
 		"last_name":     req.LastName,
 		"bio":           req.Bio,
@@ -73,12 +207,65 @@ func (u *userRepo) Create(ctx context.Context, req *entity.User) (*entity.User,
 		return nil, u.db.ErrSQLBuild(err, fmt.Sprintf("%s %s", u.tableName, "create"))
 	}
 
-	_, err = u.db.Exec(ctx, query, args...)
+	_, err = u.db.Conn(ctx).Exec(ctx, query, args...)
 	if err != nil {
-		return nil, u.db.Error(err)
+		return nil, u.error(err)
+	}
+
+	created := *req
+	created.Password = ""
+	created.Version = 1
+	return &created, nil
+}
+
+// Upsert inserts the user or overwrites profile fields of the live user with
+// the same id. Password, refresh token and creation time are only set on
+// insert, a soft deleted user is not revived and reported as not found
+func (u *userRepo) Upsert(ctx context.Context, req *entity.User, passwordHash string) (bool, error) {
+	ctx, span := otlp.Start(ctx, userServiceName, userSpanRepoPrefix+"Upsert")
+	defer span.End()
+
+	span.SetAttributes(attribute.KeyValue{Key: "User -> repository -> ", Value: attribute.StringValue("Upsert user")})
+
+	data := map[string]any{
+		"id":            req.Id,
+		"username":      req.Username,
+		"email":         req.Email,
+		"password":      passwordHash,
+		"first_name":    req.FirstName,
+		"last_name":     req.LastName,
+		"bio":           req.Bio,
+		"website":       req.Website,
+		"is_active":     req.IsActive,
+		"refresh_token": req.RefreshToken,
+		"created_at":    req.CreatedAt,
+		"updated_at":    req.UpdatedAt,
+	}
+
+	query, args, err := u.db.Sq.Builder.Insert(u.tableName).SetMap(data).
+		Suffix(`ON CONFLICT (id) DO UPDATE SET
+			username = EXCLUDED.username,
+			email = EXCLUDED.email,
+			first_name = EXCLUDED.first_name,
+			last_name = EXCLUDED.last_name,
+			bio = EXCLUDED.bio,
+			website = EXCLUDED.website,
+			is_active = EXCLUDED.is_active,
+			updated_at = EXCLUDED.updated_at,
+			version = ` + u.tableName + `.version + 1
+		WHERE ` + u.tableName + `.deleted_at IS NULL
+		RETURNING (xmax = 0)`).
+		ToSql()
+	if err != nil {
+		return false, u.db.ErrSQLBuild(err, fmt.Sprintf("%s %s", u.tableName, "upsert"))
+	}
+
+	var inserted bool
+	if err = u.db.Conn(ctx).QueryRow(ctx, query, args...).Scan(&inserted); err != nil {
+		return false, u.error(err)
 	}
 
-	return req, nil
+	return inserted, nil
 }
 
 func (u *userRepo) Get(ctx context.Context, params map[string]string) (*entity.User, error) {
@@ -87,14 +274,19 @@ func (u *userRepo) Get(ctx context.Context, params map[string]string) (*entity.U
 
 	span.SetAttributes(attribute.KeyValue{Key: "User -> repository -> ", Value: attribute.StringValue("Get user")})
 
-	var (
-		user entity.User
-	)
-
-	queryBuilder := u.usersSelectQueryPrefix()
+	queryBuilder := u.usersSelectQueryPrefix().Where(u.notDeleted())
 
+	if len(params) == 0 {
+		return nil, fmt.Errorf("%s get: no filter params given", u.tableName)
+	}
 	for key, value := range params {
-		if key == "id" {
+		caseInsensitive, ok := filterableColumns[key]
+		if !ok {
+			return nil, fmt.Errorf("%s get: unsupported filter %q", u.tableName, key)
+		}
+		if caseInsensitive {
+			queryBuilder = queryBuilder.Where(squirrel.Expr("lower("+key+") = lower(?)", value))
+		} else {
 			queryBuilder = queryBuilder.Where(squirrel.Eq{key: value})
 		}
 	}
@@ -103,151 +295,372 @@ func (u *userRepo) Get(ctx context.Context, params map[string]string) (*entity.U
 		return nil, u.db.ErrSQLBuild(err, fmt.Sprintf("%s %s", u.tableName, "get"))
 	}
 
-	var (
-		updatedAt sql.NullTime
-	)
-	if err = u.db.QueryRow(ctx, query, args...).Scan(
-		&user.Id,
-		&user.Username,
-		&user.Email,
-		&user.Password,
-		&user.FirstName,
-		&user.LastName,
-		&user.Bio,
-		&user.Website,
-		&user.IsActive,
-		&user.RefreshToken,
-		&user.CreatedAt,
-		&updatedAt,
-	); err != nil {
+	user, err := u.scanUser(u.db.Conn(ctx).QueryRow(ctx, query, args...))
+	if err != nil {
 		return nil, u.db.Error(err)
 	}
 
-	if updatedAt.Valid {
-		user.UpdatedAt = updatedAt.Time
+	return user, nil
+}
+
+// listFilter builds the conditions shared by the List page and count queries
+func (u *userRepo) listFilter(req *entity.GetListFilter) squirrel.And {
+	filter := u.db.Sq.And()
+
+	if !req.IncludeDeleted {
+		filter = append(filter, u.notDeleted())
 	}
-	return &user, nil
+	if req.IsActive != nil {
+		filter = append(filter, u.db.Sq.Equal("is_active", *req.IsActive))
+	}
+	if !req.CreatedFrom.IsZero() {
+		filter = append(filter, u.db.Sq.Gt("created_at", req.CreatedFrom))
+	}
+	if !req.CreatedTo.IsZero() {
+		filter = append(filter, u.db.Sq.Lt("created_at", req.CreatedTo))
+	}
+	if req.Search != "" {
+		pattern := "%" + likeEscaper.Replace(req.Search) + "%"
+		filter = append(filter, u.db.Sq.Or(
+			u.db.Sq.ILike("username", pattern),
+			u.db.Sq.ILike("first_name", pattern),
+			u.db.Sq.ILike("last_name", pattern),
+			u.db.Sq.ILike("email", pattern),
+		))
+	}
+
+	return filter
 }
 
-func (u *userRepo) List(ctx context.Context, req *entity.GetListFilter) ([]*entity.User, error) {
+func (u *userRepo) List(ctx context.Context, req *entity.GetListFilter) (*entity.UserList, error) {
 	ctx, span := otlp.Start(ctx, userServiceName, userSpanRepoPrefix+"List")
 	defer span.End()
 
 	span.SetAttributes(attribute.KeyValue{Key: "User -> repository -> ", Value: attribute.StringValue("Get list")})
 
 	var (
-		users []*entity.User
+		result entity.UserList
+		filter = u.listFilter(req)
 	)
-	queryBuilder := u.usersSelectQueryPrefix()
 
-	offset := (req.Page - 1) * req.Limit
+	countQuery, countArgs, err := u.db.Sq.Builder.Select("count(1)").From(u.tableName).Where(filter).ToSql()
+	if err != nil {
+		return nil, u.db.ErrSQLBuild(err, fmt.Sprintf("%s %s", u.tableName, "list count"))
+	}
+	if err = u.db.Conn(ctx).QueryRow(ctx, countQuery, countArgs...).Scan(&result.Count); err != nil {
+		return nil, u.db.Error(err)
+	}
+
+	keys, err := u.sortKeys(req.Sort)
+	if err != nil {
+		return nil, err
+	}
 
-	if req.Limit != 0 {
-		queryBuilder = queryBuilder.Limit(uint64(req.Limit)).Offset(uint64(offset))
+	queryBuilder := u.usersSelectQueryPrefix().Where(filter).OrderBy(orderByClauses(keys)...)
+
+	if req.PageToken != "" {
+		// keyset mode: continue right after the last user of the previous page
+		values, err := decodePageToken(req.PageToken, keys, listFilterDigest(req))
+		if err != nil {
+			return nil, err
+		}
+		queryBuilder = queryBuilder.Where(u.keysetAfter(keys, values))
+	} else if req.Limit != 0 {
+		queryBuilder = queryBuilder.Offset(uint64((req.Page - 1) * req.Limit))
 	}
 
-	if req.OrderBy != "" {
-		queryBuilder = queryBuilder.OrderBy(req.OrderBy)
+	if req.Limit != 0 {
+		// one extra row tells whether there is a next page
+		queryBuilder = queryBuilder.Limit(uint64(req.Limit + 1))
 	}
+
 	query, args, err := queryBuilder.ToSql()
 	if err != nil {
 		return nil, u.db.ErrSQLBuild(err, fmt.Sprintf("%s %s", u.tableName, "list"))
 	}
 
-	rows, err := u.db.Query(ctx, query, args...)
+	rows, err := u.db.Conn(ctx).Query(ctx, query, args...)
 	if err != nil {
 		return nil, u.db.Error(err)
 	}
 	defer rows.Close()
-	var (
-		updatedAt sql.NullTime
-	)
 	for rows.Next() {
-		var user entity.User
-		if err = rows.Scan(
-			&user.Id,
-			&user.Username,
-			&user.Email,
-			&user.Password,
-			&user.FirstName,
-			&user.LastName,
-			&user.Bio,
-			&user.Website,
-			&user.IsActive,
-			&user.RefreshToken,
-			&user.CreatedAt,
-			&updatedAt,
-		); err != nil {
+		user, err := u.scanUser(rows)
+		if err != nil {
 			return nil, u.db.Error(err)
 		}
+		result.Users = append(result.Users, user)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, u.db.Error(err)
+	}
 
-		if updatedAt.Valid {
-			user.UpdatedAt = updatedAt.Time
+	if req.Limit != 0 && int64(len(result.Users)) > req.Limit {
+		result.Users = result.Users[:req.Limit]
+		result.NextPageToken, err = encodePageToken(keys, result.Users[req.Limit-1], listFilterDigest(req))
+		if err != nil {
+			return nil, err
 		}
-		users = append(users, &user)
 	}
 
-	return users, nil
+	return &result, nil
 }
 
-func (u *userRepo) Update(ctx context.Context, req *entity.User) error {
+// Update writes only the given fields of req, fields are checked against updatableColumns
+func (u *userRepo) Update(ctx context.Context, req *entity.User, fields []string) error {
 	ctx, span := otlp.Start(ctx, userServiceName, userSpanRepoPrefix+"Update")
 	defer span.End()
 
 	span.SetAttributes(attribute.KeyValue{Key: "User -> repository -> ", Value: attribute.StringValue("Update user")})
 
 	data := map[string]any{
-		"first_name": req.FirstName,
-		"last_name":  req.LastName,
-		"username":   req.Username,
-		"bio":        req.Bio,
-		"website":    req.Website,
-		"is_active":  req.IsActive,
 		"updated_at": req.UpdatedAt,
+		"version":    squirrel.Expr("version + 1"),
+	}
+	for _, field := range fields {
+		value, ok := updatableColumns[field]
+		if !ok {
+			return fmt.Errorf("%s update: unsupported field %q", u.tableName, field)
+		}
+		data[field] = value(req)
 	}
 
 	sqlStr, args, err := u.db.Sq.Builder.
 		Update(u.tableName).
 		SetMap(data).
 		Where(squirrel.Eq{"id": req.Id}).
+		Where(u.notDeleted()).
+		Where(u.versionIs(req.Version)).
 		ToSql()
 	if err != nil {
 		return u.db.ErrSQLBuild(err, u.tableName+" update")
 	}
 
-	commandTag, err := u.db.Exec(ctx, sqlStr, args...)
+	commandTag, err := u.db.Conn(ctx).Exec(ctx, sqlStr, args...)
 	if err != nil {
-		return u.db.Error(err)
+		return u.error(err)
 	}
 
 	if commandTag.RowsAffected() == 0 {
-		return u.db.Error(fmt.Errorf("no sql rows"))
+		return u.notUpdated(ctx, req.Id, req.Version)
 	}
 
 	return nil
 }
 
-func (u *userRepo) Delete(ctx context.Context, id string) error {
+func (u *userRepo) Delete(ctx context.Context, id string, version int64) (*entity.User, error) {
 	ctx, span := otlp.Start(ctx, userServiceName, userSpanRepoPrefix+"Delete")
 	defer span.End()
 
 	span.SetAttributes(attribute.KeyValue{Key: "User -> repository -> ", Value: attribute.StringValue("Delete user")})
 
+	sqlStr, args, err := u.db.Sq.Builder.
+		Update(u.tableName).
+		Set("deleted_at", time.Now().UTC()).
+		Set("version", squirrel.Expr("version + 1")).
+		Where(u.db.Sq.Equal("id", id)).
+		Where(u.notDeleted()).
+		Where(u.versionIs(version)).
+		Suffix("RETURNING version, deleted_at").
+		ToSql()
+	if err != nil {
+		return nil, u.db.ErrSQLBuild(err, u.tableName+" delete")
+	}
+
+	deleted := entity.User{Id: id}
+	if err = u.db.Conn(ctx).QueryRow(ctx, sqlStr, args...).Scan(&deleted.Version, &deleted.DeletedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, u.notUpdated(ctx, id, version)
+		}
+		return nil, u.db.Error(err)
+	}
+
+	return &deleted, nil
+}
+
+// Restore brings back a soft deleted user
+func (u *userRepo) Restore(ctx context.Context, id string) error {
+	ctx, span := otlp.Start(ctx, userServiceName, userSpanRepoPrefix+"Restore")
+	defer span.End()
+
+	span.SetAttributes(attribute.KeyValue{Key: "User -> repository -> ", Value: attribute.StringValue("Restore user")})
+
+	sqlStr, args, err := u.db.Sq.Builder.
+		Update(u.tableName).
+		Set("deleted_at", nil).
+		Set("version", squirrel.Expr("version + 1")).
+		Where(u.db.Sq.Equal("id", id)).
+		Where(u.db.Sq.NotEqual("deleted_at", nil)).
+		ToSql()
+	if err != nil {
+		return u.db.ErrSQLBuild(err, u.tableName+" restore")
+	}
+
+	commandTag, err := u.db.Conn(ctx).Exec(ctx, sqlStr, args...)
+	if err != nil {
+		return u.error(err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return entity.NewErrNotFound("deleted user")
+	}
+
+	return nil
+}
+
+// Purge hard deletes users that were soft deleted before the given time
+func (u *userRepo) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	ctx, span := otlp.Start(ctx, userServiceName, userSpanRepoPrefix+"Purge")
+	defer span.End()
+
+	span.SetAttributes(attribute.KeyValue{Key: "User -> repository -> ", Value: attribute.StringValue("Purge users")})
+
 	sqlStr, args, err := u.db.Sq.Builder.
 		Delete(u.tableName).
+		Where(u.db.Sq.Lt("deleted_at", deletedBefore)).
+		ToSql()
+	if err != nil {
+		return 0, u.db.ErrSQLBuild(err, u.tableName+" purge")
+	}
+
+	commandTag, err := u.db.Conn(ctx).Exec(ctx, sqlStr, args...)
+	if err != nil {
+		return 0, u.db.Error(err)
+	}
+
+	return commandTag.RowsAffected(), nil
+}
+
+func (u *userRepo) CheckField(ctx context.Context, field, value string) (bool, error) {
+	ctx, span := otlp.Start(ctx, userServiceName, userSpanRepoPrefix+"CheckField")
+	defer span.End()
+
+	span.SetAttributes(attribute.KeyValue{Key: "User -> repository -> ", Value: attribute.StringValue("Check field")})
+
+	column, ok := checkableColumns[field]
+	if !ok {
+		return false, fmt.Errorf("%s check field: unsupported field %q", u.tableName, field)
+	}
+
+	query, args, err := u.db.Sq.Builder.
+		Select("count(1)").
+		From(u.tableName).
+		Where(squirrel.Expr("lower("+column+") = lower(?)", value)).
+		Where(u.notDeleted()).
+		ToSql()
+	if err != nil {
+		return false, u.db.ErrSQLBuild(err, u.tableName+" check field")
+	}
+
+	var count int64
+	if err = u.db.Conn(ctx).QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return false, u.db.Error(err)
+	}
+
+	return count != 0, nil
+}
+
+// UpdateRefreshToken replaces the stored refresh token hash in a single statement
+func (u *userRepo) UpdateRefreshToken(ctx context.Context, id, refreshToken string, updatedAt time.Time) error {
+	ctx, span := otlp.Start(ctx, userServiceName, userSpanRepoPrefix+"UpdateRefreshToken")
+	defer span.End()
+
+	span.SetAttributes(attribute.KeyValue{Key: "User -> repository -> ", Value: attribute.StringValue("Update refresh token")})
+
+	sqlStr, args, err := u.db.Sq.Builder.
+		Update(u.tableName).
+		SetMap(map[string]any{
+			"refresh_token": refreshToken,
+			"updated_at":    updatedAt,
+		}).
 		Where(u.db.Sq.Equal("id", id)).
+		Where(u.notDeleted()).
 		ToSql()
 	if err != nil {
-		return u.db.ErrSQLBuild(err, u.tableName+" delete")
+		return u.db.ErrSQLBuild(err, u.tableName+" update refresh token")
 	}
 
-	commandTag, err := u.db.Exec(ctx, sqlStr, args...)
+	commandTag, err := u.db.Conn(ctx).Exec(ctx, sqlStr, args...)
 	if err != nil {
 		return u.db.Error(err)
 	}
 
 	if commandTag.RowsAffected() == 0 {
-		return u.db.Error(fmt.Errorf("no sql rows"))
+		return entity.NewErrNotFound("user")
+	}
+
+	return nil
+}
+
+// VerifyCredentials checks password of the user found by field (username or
+// email). Every failure is reported as entity.ErrorInvalidCredentials. On
+// success a hash made with outdated cost, or a password stored in plain text
+// before passwords were hashed, is replaced with a fresh hash
+func (u *userRepo) VerifyCredentials(ctx context.Context, field, login, plain string) (*entity.User, error) {
+	ctx, span := otlp.Start(ctx, userServiceName, userSpanRepoPrefix+"VerifyCredentials")
+	defer span.End()
+
+	span.SetAttributes(attribute.KeyValue{Key: "User -> repository -> ", Value: attribute.StringValue("Verify credentials")})
+
+	column, ok := checkableColumns[field]
+	if !ok {
+		return nil, fmt.Errorf("%s verify credentials: unsupported field %q", u.tableName, field)
+	}
+
+	query, args, err := u.usersSelectQueryPrefix().
+		Column("password").
+		Where(squirrel.Expr("lower("+column+") = lower(?)", login)).
+		Where(u.notDeleted()).
+		ToSql()
+	if err != nil {
+		return nil, u.db.ErrSQLBuild(err, u.tableName+" verify credentials")
+	}
+
+	var passwordHash string
+	user, err := u.scanUser(u.db.Conn(ctx).QueryRow(ctx, query, args...), &passwordHash)
+	if err != nil {
+		if err = u.db.Error(err); errors.Is(err, entity.ErrorNotFound) {
+			u.hasher.CompareDummy(plain)
+			return nil, entity.ErrorInvalidCredentials
+		}
+		return nil, err
+	}
+
+	if err = u.hasher.Compare(passwordHash, plain); err != nil {
+		if errors.Is(err, password.ErrMismatch) {
+			return nil, entity.ErrorInvalidCredentials
+		}
+		return nil, err
+	}
+
+	if u.hasher.NeedsRehash(passwordHash) {
+		// login already succeeded, failed rehash is retried on the next one
+		if err = u.rehashPassword(ctx, user.Id, passwordHash, plain); err != nil {
+			span.RecordError(err)
+		}
+	}
+
+	return user, nil
+}
+
+func (u *userRepo) rehashPassword(ctx context.Context, id, oldHash, plain string) error {
+	newHash, err := u.hasher.Hash(plain)
+	if err != nil {
+		return err
+	}
+
+	sqlStr, args, err := u.db.Sq.Builder.
+		Update(u.tableName).
+		Set("password", newHash).
+		Where(u.db.Sq.Equal("id", id)).
+		Where(u.db.Sq.Equal("password", oldHash)).
+		ToSql()
+	if err != nil {
+		return u.db.ErrSQLBuild(err, u.tableName+" rehash password")
+	}
+
+	if _, err = u.db.Conn(ctx).Exec(ctx, sqlStr, args...); err != nil {
+		return u.db.Error(err)
 	}
 
 	return nil
@@ -0,0 +1,214 @@
+package postgresql
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"fourth-exam/user-service-evrone/internal/entity"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type sortColumn struct {
+	expr   string
+	isTime bool
+}
+
+// sortableColumns maps the fields List can be ordered by to their SQL
+// expressions. updated_at is empty until the first update, so it falls back
+// to created_at to keep keyset comparisons away from NULLs
+var sortableColumns = map[string]sortColumn{
+	"id":         {expr: "id"},
+	"username":   {expr: "username"},
+	"email":      {expr: "email"},
+	"first_name": {expr: "first_name"},
+	"last_name":  {expr: "last_name"},
+	"created_at": {expr: "created_at", isTime: true},
+	"updated_at": {expr: "coalesce(updated_at, created_at)", isTime: true},
+}
+
+type sortKey struct {
+	field  string
+	column sortColumn
+	desc   bool
+}
+
+// CanSortBy reports whether List can order users by field, the usecase
+// validates requests against it so sortableColumns is the only list
+func (u *userRepo) CanSortBy(field string) bool {
+	_, ok := sortableColumns[field]
+	return ok
+}
+
+// sortKeys resolves sort fields against the whitelist and appends id as
+// tiebreaker so the order, and therefore every page, is deterministic
+func (u *userRepo) sortKeys(sort []entity.SortField) ([]sortKey, error) {
+	keys := make([]sortKey, 0, len(sort)+1)
+	for _, field := range sort {
+		column, ok := sortableColumns[field.Field]
+		if !ok {
+			errValidation := entity.NewErrValidation()
+			errValidation.Err = fmt.Errorf("%s list: unsupported order by %q", u.tableName, field.Field)
+			errValidation.Errors["order_by"] = fmt.Sprintf("can not order by %q", field.Field)
+			return nil, errValidation
+		}
+		keys = append(keys, sortKey{field: field.Field, column: column, desc: field.Desc})
+		if field.Field == "id" {
+			// id is unique, fields after it can not change the order
+			return keys, nil
+		}
+	}
+	return append(keys, sortKey{field: "id", column: sortableColumns["id"]}), nil
+}
+
+func orderByClauses(keys []sortKey) []string {
+	clauses := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key.desc {
+			clauses = append(clauses, key.column.expr+" DESC")
+		} else {
+			clauses = append(clauses, key.column.expr+" ASC")
+		}
+	}
+	return clauses
+}
+
+// keysetAfter matches rows placed after values in keys order:
+// (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ..., with < for descending keys
+func (u *userRepo) keysetAfter(keys []sortKey, values []any) squirrel.Sqlizer {
+	after := u.db.Sq.Or()
+	for i, key := range keys {
+		cond := u.db.Sq.And()
+		for j := 0; j < i; j++ {
+			cond = append(cond, u.db.Sq.Equal(keys[j].column.expr, values[j]))
+		}
+		if key.desc {
+			cond = append(cond, u.db.Sq.Lt(key.column.expr, values[i]))
+		} else {
+			cond = append(cond, u.db.Sq.Gt(key.column.expr, values[i]))
+		}
+		after = append(after, cond)
+	}
+	return after
+}
+
+// pageToken is the opaque next_page_token, it remembers the sort values of
+// the last returned user and the order and filter they belong to
+type pageToken struct {
+	Order  string   `json:"o"`
+	Filter string   `json:"f"`
+	Values []string `json:"v"`
+}
+
+func orderSignature(keys []sortKey) string {
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key.desc {
+			parts = append(parts, key.field+" desc")
+		} else {
+			parts = append(parts, key.field)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// listFilterDigest fingerprints the filter, a token can not be replayed
+// against a different filter
+func listFilterDigest(req *entity.GetListFilter) string {
+	var isActive string
+	if req.IsActive != nil {
+		isActive = fmt.Sprint(*req.IsActive)
+	}
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%t|%s|%s|%s|%s",
+		req.IncludeDeleted,
+		isActive,
+		req.CreatedFrom.Format(time.RFC3339Nano),
+		req.CreatedTo.Format(time.RFC3339Nano),
+		req.Search,
+	)))
+	return hex.EncodeToString(sum[:8])
+}
+
+func sortValue(user *entity.User, field string) string {
+	switch field {
+	case "id":
+		return user.Id
+	case "username":
+		return user.Username
+	case "email":
+		return user.Email
+	case "first_name":
+		return user.FirstName
+	case "last_name":
+		return user.LastName
+	case "created_at":
+		return user.CreatedAt.Format(time.RFC3339Nano)
+	case "updated_at":
+		if user.UpdatedAt.IsZero() {
+			return user.CreatedAt.Format(time.RFC3339Nano)
+		}
+		return user.UpdatedAt.Format(time.RFC3339Nano)
+	}
+	return ""
+}
+
+func encodePageToken(keys []sortKey, last *entity.User, filterDigest string) (string, error) {
+	token := pageToken{
+		Order:  orderSignature(keys),
+		Filter: filterDigest,
+		Values: make([]string, 0, len(keys)),
+	}
+	for _, key := range keys {
+		token.Values = append(token.Values, sortValue(last, key.field))
+	}
+
+	data, err := json.Marshal(token)
+	if err != nil {
+		return "", fmt.Errorf("error during page token encode: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodePageToken(encoded string, keys []sortKey, filterDigest string) ([]any, error) {
+	var token pageToken
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err == nil {
+		err = json.Unmarshal(data, &token)
+	}
+	if err != nil || len(token.Values) != len(keys) {
+		return nil, errInvalidPageToken("is malformed")
+	}
+	if token.Order != orderSignature(keys) {
+		return nil, errInvalidPageToken("was issued for another order_by")
+	}
+	if token.Filter != filterDigest {
+		return nil, errInvalidPageToken("was issued for another filter")
+	}
+
+	values := make([]any, 0, len(keys))
+	for i, key := range keys {
+		if !key.column.isTime {
+			values = append(values, token.Values[i])
+			continue
+		}
+		value, err := time.Parse(time.RFC3339Nano, token.Values[i])
+		if err != nil {
+			return nil, errInvalidPageToken("is malformed")
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func errInvalidPageToken(description string) error {
+	errValidation := entity.NewErrValidation()
+	errValidation.Err = errors.New("invalid page token")
+	errValidation.Errors["page_token"] = description
+	return errValidation
+}
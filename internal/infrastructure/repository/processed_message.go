@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+type ProcessedMessage interface {
+	// Add records message of consumer and reports false if it was already recorded
+	Add(ctx context.Context, consumer, messageID string, processedAt time.Time) (bool, error)
+	DeleteBefore(ctx context.Context, processedBefore time.Time) (int64, error)
+}
@@ -0,0 +1,10 @@
+package repository
+
+import "context"
+
+// Transactor runs fn as a single unit of work. Repository calls made with the
+// context passed to fn share one transaction, which is rolled back when fn
+// returns an error or panics
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
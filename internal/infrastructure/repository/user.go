@@ -2,12 +2,24 @@ package repository
 import (
 	"context"
 	"fourth-exam/user-service-evrone/internal/entity"
+	"time"
 )
 
 type User interface {
-	Create(ctx context.Context, req *entity.User) (*entity.User, error)
+	// Create stores passwordHash as the password of the user, req.Password is not written
+	Create(ctx context.Context, req *entity.User, passwordHash string) (*entity.User, error)
+	// Upsert creates the user or updates its profile by id and reports whether it was created
+	Upsert(ctx context.Context, req *entity.User, passwordHash string) (bool, error)
 	Get(ctx context.Context, params map[string]string) (*entity.User, error)
-	List(ctx context.Context, req *entity.GetListFilter) ([]*entity.User, error)
-	Update(ctx context.Context, req *entity.User) (error)
-	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, req *entity.GetListFilter) (*entity.UserList, error)
+	// CanSortBy reports whether List can order users by field
+	CanSortBy(field string) bool
+	Update(ctx context.Context, req *entity.User, fields []string) error
+	// Delete soft deletes the user and returns its id, new version and deletion time
+	Delete(ctx context.Context, id string, version int64) (*entity.User, error)
+	CheckField(ctx context.Context, field, value string) (bool, error)
+	UpdateRefreshToken(ctx context.Context, id, refreshToken string, updatedAt time.Time) error
+	Restore(ctx context.Context, id string) error
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
+	VerifyCredentials(ctx context.Context, field, login, password string) (*entity.User, error)
 }
\ No newline at end of file
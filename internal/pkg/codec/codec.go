@@ -0,0 +1,141 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"strings"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+)
+
+const (
+	// ContentTypeJSON is plain encoding/json, the format of messages without content type
+	ContentTypeJSON = "application/json"
+	// ContentTypeProtoJSON is the canonical protobuf JSON mapping, it
+	// accepts both lowerCamelCase and original field names
+	ContentTypeProtoJSON = "application/x-protojson"
+	// ContentTypeProtobuf is protobuf binary wire format
+	ContentTypeProtobuf = "application/x-protobuf"
+)
+
+type Codec interface {
+	ContentType() string
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// Registry picks a codec by content type
+type Registry struct {
+	codecs   map[string]Codec
+	fallback Codec
+}
+
+// New returns registry of JSON, protobuf JSON and protobuf binary codecs,
+// messages without content type are decoded as JSON
+func New() *Registry {
+	r := &Registry{codecs: make(map[string]Codec)}
+	r.Register(JSON{})
+	r.Register(ProtoJSON{})
+	r.Register(Protobuf{})
+	r.fallback = JSON{}
+	return r
+}
+
+// Register adds codec or replaces the one of the same content type
+func (r *Registry) Register(codec Codec) {
+	r.codecs[codec.ContentType()] = codec
+}
+
+// Get returns codec of contentType, parameters like charset are ignored
+// and empty contentType gives the fallback codec
+func (r *Registry) Get(contentType string) (Codec, error) {
+	if strings.TrimSpace(contentType) == "" {
+		return r.fallback, nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid content type %q: %w", contentType, err)
+	}
+
+	codec, ok := r.codecs[mediaType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported content type %q", contentType)
+	}
+	return codec, nil
+}
+
+type JSON struct{}
+
+func (JSON) ContentType() string {
+	return ContentTypeJSON
+}
+
+func (JSON) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (JSON) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+type ProtoJSON struct{}
+
+func (ProtoJSON) ContentType() string {
+	return ContentTypeProtoJSON
+}
+
+func (ProtoJSON) Marshal(v any) ([]byte, error) {
+	message, err := protoMessage(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := (&jsonpb.Marshaler{}).Marshal(&buf, message); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (ProtoJSON) Unmarshal(data []byte, v any) error {
+	message, err := protoMessage(v)
+	if err != nil {
+		return err
+	}
+	// fields added by newer producers are skipped, like in binary format
+	return (&jsonpb.Unmarshaler{AllowUnknownFields: true}).Unmarshal(bytes.NewReader(data), message)
+}
+
+type Protobuf struct{}
+
+func (Protobuf) ContentType() string {
+	return ContentTypeProtobuf
+}
+
+func (Protobuf) Marshal(v any) ([]byte, error) {
+	message, err := protoMessage(v)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(message)
+}
+
+func (Protobuf) Unmarshal(data []byte, v any) error {
+	message, err := protoMessage(v)
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(data, message)
+}
+
+func protoMessage(v any) (proto.Message, error) {
+	message, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a protobuf message", v)
+	}
+	return message, nil
+}
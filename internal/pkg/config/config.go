@@ -15,6 +15,24 @@ type Config struct {
 		Timeout string
 	}
 
+	Purge struct {
+		Retention string
+	}
+
+	Inbox struct {
+		Retention string
+	}
+
+	Password struct {
+		HashCost string
+	}
+
+	Outbox struct {
+		PollInterval string
+		BatchSize    string
+		Retention    string
+	}
+
 	DB struct {
 		Host     string
 		Port     string
@@ -29,10 +47,36 @@ type Config struct {
 		Port string
 	}
 
+	Metrics struct {
+		Address string
+	}
+
+	Broker struct {
+		// Driver is kafka or memory, the memory broker lives in the process
+		// and is meant for tests and local development
+		Driver     string
+		Partitions string
+	}
+
 	Kafka struct {
-		Address []string
-		Topic   struct {
-			UserTopic string
+		Address  []string
+		Consumer struct {
+			Workers        string
+			MaxAttempts    string
+			InitialBackoff string
+			MaxBackoff     string
+			// ShutdownTimeout bounds waiting for running handlers on stop
+			ShutdownTimeout string
+		}
+		Producer struct {
+			BatchSize    string
+			BatchTimeout string
+			Async        string
+		}
+		Topic struct {
+			UserTopic           string
+			UserTopicDeadLetter string
+			UserEvents          string
 		}
 	}
 }
@@ -47,6 +91,22 @@ func New() *Config {
 	config.RPCPort = getEnv("RPC_PORT", ":9090")
 	config.Context.Timeout = getEnv("CONTEXT_TIMEOUT", "30s")
 
+	// soft deleted users are purged after retention period
+	config.Purge.Retention = getEnv("PURGE_RETENTION", "720h")
+
+	// processed message ids are kept for retention to skip redelivered messages,
+	// it must be at least as long as retention of the consumed topics
+	config.Inbox.Retention = getEnv("INBOX_RETENTION", "336h")
+
+	// bcrypt cost of password hashes, changing it rehashes passwords on next login
+	config.Password.HashCost = getEnv("PASSWORD_HASH_COST", "12")
+
+	// outbox relay polls for unsent user events and publishes them in batches
+	config.Outbox.PollInterval = getEnv("OUTBOX_POLL_INTERVAL", "1s")
+	config.Outbox.BatchSize = getEnv("OUTBOX_BATCH_SIZE", "100")
+	// sent user events are kept for retention to look into what was published
+	config.Outbox.Retention = getEnv("OUTBOX_RETENTION", "168h")
+
 	// db configuration
 	config.DB.Host = getEnv("POSTGRES_HOST", "localhost")
 	config.DB.Port = getEnv("POSTGRES_PORT", "5432")
@@ -58,13 +118,36 @@ func New() *Config {
 	config.OTLPCollector.Host = getEnv("OTLP_COLLECTOR_HOST", "0.0.0.0")
 	config.OTLPCollector.Port = getEnv("OTLP_COLLECTOR_PORT", ":4317")
 
+	// prometheus metrics are served at /metrics, processes on one host need distinct addresses
+	config.Metrics.Address = getEnv("METRICS_ADDRESS", ":9100")
+
+	// message broker, topics of the memory broker get Partitions partitions
+	config.Broker.Driver = getEnv("BROKER_DRIVER", "kafka")
+	config.Broker.Partitions = getEnv("BROKER_MEMORY_PARTITIONS", "3")
+
 	// kafka configuration
 	config.Kafka.Address = strings.Split(getEnv("KAFKA_ADDRESS", "localhost:9092"), ",")
+	config.Kafka.Consumer.Workers = getEnv("KAFKA_CONSUMER_WORKERS", "4")
+	config.Kafka.Consumer.MaxAttempts = getEnv("KAFKA_CONSUMER_MAX_ATTEMPTS", "5")
+	config.Kafka.Consumer.InitialBackoff = getEnv("KAFKA_CONSUMER_INITIAL_BACKOFF", "200ms")
+	config.Kafka.Consumer.MaxBackoff = getEnv("KAFKA_CONSUMER_MAX_BACKOFF", "10s")
+	config.Kafka.Consumer.ShutdownTimeout = getEnv("KAFKA_CONSUMER_SHUTDOWN_TIMEOUT", "30s")
+	config.Kafka.Producer.BatchSize = getEnv("KAFKA_PRODUCER_BATCH_SIZE", "100")
+	config.Kafka.Producer.BatchTimeout = getEnv("KAFKA_PRODUCER_BATCH_TIMEOUT", "10ms")
+	config.Kafka.Producer.Async = getEnv("KAFKA_PRODUCER_ASYNC", "false")
 	config.Kafka.Topic.UserTopic = getEnv("KAFKA_TOPIC_USER_SERVICE", "user.service.create")
+	config.Kafka.Topic.UserTopicDeadLetter = getEnv("KAFKA_TOPIC_USER_SERVICE_DLQ", "user.service.create.dlq")
+	config.Kafka.Topic.UserEvents = getEnv("KAFKA_TOPIC_USER_EVENTS", "user.events")
 
 	return &config
 }
 
+// ConsumerEnv returns setting of the named consumer from env
+// KAFKA_CONSUMER_<NAME>_<KEY>, e.g. KAFKA_CONSUMER_USER_COMMANDS_GROUP_ID, or defaultValue
+func (c *Config) ConsumerEnv(name, key, defaultValue string) string {
+	return getEnv("KAFKA_CONSUMER_"+strings.ToUpper(name)+"_"+strings.ToUpper(key), defaultValue)
+}
+
 func getEnv(key string, defaultVaule string) string {
 	value, exists := os.LookupEnv(key)
 	if exists {
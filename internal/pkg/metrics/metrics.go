@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// consumer metrics are labeled with the topic and the group of the consumer,
+// offset and lag also with the partition
+var (
+	ConsumerMessagesProcessed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "consumer_messages_processed_total",
+		Help: "Messages handled successfully.",
+	}, []string{"topic", "group"})
+
+	ConsumerMessagesFailed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "consumer_messages_failed_total",
+		Help: "Messages the handler gave up on, they are dead-lettered or skipped.",
+	}, []string{"topic", "group"})
+
+	ConsumerMessagesRetried = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "consumer_messages_retried_total",
+		Help: "Handler attempts retried after a failure.",
+	}, []string{"topic", "group"})
+
+	ConsumerMessagesDeadLettered = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "consumer_messages_dead_lettered_total",
+		Help: "Failed messages written to the dead-letter topic.",
+	}, []string{"topic", "group"})
+
+	ConsumerHandlerDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "consumer_handler_duration_seconds",
+		Help:    "Duration of a handler attempt.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"topic", "group"})
+
+	ConsumerOffset = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "consumer_offset",
+		Help: "Offset of the last fetched message of the partition.",
+	}, []string{"topic", "group", "partition"})
+
+	ConsumerLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "consumer_lag",
+		Help: "Messages of the partition produced after the last fetched one.",
+	}, []string{"topic", "group", "partition"})
+
+	// reader metrics come from kafka-go reader stats, which a group reader
+	// keeps for all of its partitions together
+	ConsumerReaderLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "consumer_reader_lag",
+		Help: "Lag of the reader as last reported by the broker client.",
+	}, []string{"topic", "group"})
+
+	ConsumerReaderErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "consumer_reader_errors_total",
+		Help: "Errors of the reader while fetching or committing.",
+	}, []string{"topic", "group"})
+
+	ConsumerReaderRebalances = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "consumer_reader_rebalances_total",
+		Help: "Rebalances of the reader's consumer group.",
+	}, []string{"topic", "group"})
+)
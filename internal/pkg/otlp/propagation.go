@@ -0,0 +1,22 @@
+package otlp
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// traceContext propagates W3C traceparent and tracestate whether or not
+// the provider of this process is initialized
+var traceContext = propagation.TraceContext{}
+
+// InjectTraceContext writes trace context of the span in ctx into carrier
+func InjectTraceContext(ctx context.Context, carrier propagation.TextMapCarrier) {
+	traceContext.Inject(ctx, carrier)
+}
+
+// ExtractTraceContext returns ctx carrying the remote span of carrier,
+// spans started from it belong to the upstream trace
+func ExtractTraceContext(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return traceContext.Extract(ctx, carrier)
+}
@@ -0,0 +1,102 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"fourth-exam/user-service-evrone/internal/pkg/config"
+	"strconv"
+	"sync"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var ErrMismatch = errors.New("password does not match")
+
+// Hasher hashes passwords with bcrypt using the cost from configuration
+type Hasher struct {
+	cost int
+
+	dummyOnce sync.Once
+	dummyHash []byte
+}
+
+func New(config *config.Config) (*Hasher, error) {
+	cost, err := strconv.Atoi(config.Password.HashCost)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse password hash cost: %w", err)
+	}
+
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		return nil, fmt.Errorf("password hash cost must be between %d and %d, got %d", bcrypt.MinCost, bcrypt.MaxCost, cost)
+	}
+
+	return &Hasher{cost: cost}, nil
+}
+
+func (h *Hasher) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if err != nil {
+		return "", fmt.Errorf("error during password hash: %w", err)
+	}
+	return string(hash), nil
+}
+
+// Compare checks password against hash, ErrMismatch is returned when they do not match.
+// Passwords stored in plain text before they were hashed are compared as
+// they are, NeedsRehash reports them so callers replace them with a hash
+func (h *Hasher) Compare(hash, password string) error {
+	if !isHash(hash) {
+		return h.comparePlain(hash, password)
+	}
+
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, bcrypt.ErrMismatchedHashAndPassword), errors.Is(err, bcrypt.ErrHashTooShort):
+		return ErrMismatch
+	default:
+		return err
+	}
+}
+
+// NeedsRehash reports whether hash was made with other cost than the
+// configured one or is a password stored in plain text
+func (h *Hasher) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	if err != nil {
+		return true
+	}
+	return cost != h.cost
+}
+
+// comparePlain compares a password stored in plain text in constant time,
+// spending as long as a bcrypt comparison so such rows are not told apart.
+// Users stored without a password never match
+func (h *Hasher) comparePlain(stored, password string) error {
+	h.CompareDummy(password)
+	if stored == "" || subtle.ConstantTimeCompare([]byte(stored), []byte(password)) != 1 {
+		return ErrMismatch
+	}
+	return nil
+}
+
+// isHash reports whether stored is a bcrypt hash rather than a plain text password
+func isHash(stored string) bool {
+	_, err := bcrypt.Cost([]byte(stored))
+	return err == nil
+}
+
+// CompareDummy spends the same time as Compare does for an existing user,
+// so callers do not reveal whether the login exists
+func (h *Hasher) CompareDummy(password string) {
+	h.dummyOnce.Do(func() {
+		random := make([]byte, 16)
+		_, _ = rand.Read(random)
+		h.dummyHash, _ = bcrypt.GenerateFromPassword([]byte(hex.EncodeToString(random)), h.cost)
+	})
+	_ = bcrypt.CompareHashAndPassword(h.dummyHash, []byte(password))
+}
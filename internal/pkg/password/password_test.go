@@ -0,0 +1,52 @@
+package password
+
+import (
+	"errors"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestHasherCompare(t *testing.T) {
+	var (
+		hasher  = &Hasher{cost: bcrypt.MinCost}
+		current = mustHash(t, "secret", bcrypt.MinCost)
+		stale   = mustHash(t, "secret", bcrypt.MinCost+1)
+	)
+
+	tests := []struct {
+		name        string
+		stored      string
+		password    string
+		wantErr     error
+		needsRehash bool
+	}{
+		{name: "hash matches", stored: current, password: "secret"},
+		{name: "hash does not match", stored: current, password: "other", wantErr: ErrMismatch},
+		{name: "hash of other cost", stored: stale, password: "secret", needsRehash: true},
+		{name: "plain text matches", stored: "secret", password: "secret", needsRehash: true},
+		{name: "plain text does not match", stored: "secret", password: "secre", wantErr: ErrMismatch, needsRehash: true},
+		{name: "no password stored", stored: "", password: "", wantErr: ErrMismatch, needsRehash: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := hasher.Compare(tt.stored, tt.password); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Compare() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := hasher.NeedsRehash(tt.stored); got != tt.needsRehash {
+				t.Fatalf("NeedsRehash() = %v, want %v", got, tt.needsRehash)
+			}
+		})
+	}
+}
+
+func mustHash(t *testing.T, password string, cost int) string {
+	t.Helper()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), cost)
+	if err != nil {
+		t.Fatalf("hash password: %v", err)
+	}
+	return string(hash)
+}
@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	txMaxAttempts    = 5
+	txRetryBaseDelay = 10 * time.Millisecond
+)
+
+var _ RepoTx = (*PostgresDB)(nil)
+
+type ctxKeyTx struct{}
+
+// ctxKeyTxOptions carries the options the running transaction was started with
+type ctxKeyTxOptions struct{}
+
+// Querier is implemented by both the pool and a transaction
+type Querier interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// Beging starts a transaction on the pool
+func (p *PostgresDB) Beging(ctx context.Context) (Tx, error) {
+	tx, err := p.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to begin transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// TxRollBack rolls tx back when err is not nil, rolling back a finished
+// transaction is a no-op
+func (p *PostgresDB) TxRollBack(ctx context.Context, tx Tx, err error) {
+	if err == nil {
+		return
+	}
+	_ = tx.Rollback(ctx)
+}
+
+// Conn returns the transaction carried by ctx, or the pool outside of one.
+// Repositories run their queries through it to join the caller's unit of work
+func (p *PostgresDB) Conn(ctx context.Context) Querier {
+	if tx, ok := ctx.Value(ctxKeyTx{}).(Tx); ok {
+		return tx
+	}
+	return p.Pool
+}
+
+// WithinTransaction runs fn as one unit of work: every repository call made
+// with the context given to fn uses the same transaction, which is committed
+// when fn returns nil and rolled back on error or panic. Transactions run
+// at the default READ COMMITTED level, where only deadlocks restart fn from
+// scratch. Calls nested into an already running unit of work join it
+func (p *PostgresDB) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return p.WithinTransactionOptions(ctx, pgx.TxOptions{}, fn)
+}
+
+// WithinTransactionOptions runs fn like WithinTransaction in a transaction
+// started with opts. At REPEATABLE READ and SERIALIZABLE, serialization
+// failures restart fn from scratch too, so fn must not have effects outside
+// of the transaction. A nested call joins the running unit of work and fails
+// when it asks for another isolation level
+func (p *PostgresDB) WithinTransactionOptions(ctx context.Context, opts pgx.TxOptions, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(ctxKeyTx{}).(Tx); ok {
+		if outer, _ := ctx.Value(ctxKeyTxOptions{}).(pgx.TxOptions); opts.IsoLevel != "" && opts.IsoLevel != outer.IsoLevel {
+			return fmt.Errorf("unable to join transaction: isolation level %q of the running one is not %q", outer.IsoLevel, opts.IsoLevel)
+		}
+		return fn(ctx)
+	}
+
+	var err error
+	for attempt := 1; attempt <= txMaxAttempts; attempt++ {
+		if err = p.runTransaction(ctx, opts, fn); !retryable(err) || attempt == txMaxAttempts {
+			break
+		}
+
+		delay := txRetryBaseDelay << (attempt - 1)
+		delay += time.Duration(rand.Int63n(int64(delay)))
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %v", ctx.Err(), err)
+		case <-time.After(delay):
+		}
+	}
+	return err
+}
+
+func (p *PostgresDB) runTransaction(ctx context.Context, opts pgx.TxOptions, fn func(ctx context.Context) error) (err error) {
+	tx, err := p.Pool.BeginTx(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("unable to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			p.TxRollBack(ctx, tx, fmt.Errorf("panic: %v", r))
+			panic(r)
+		}
+		p.TxRollBack(ctx, tx, err)
+	}()
+
+	ctx = context.WithValue(ctx, ctxKeyTxOptions{}, opts)
+	if err = fn(context.WithValue(ctx, ctxKeyTx{}, Tx(tx))); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("unable to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// retryable reports serialization failures and deadlocks, the transaction
+// may succeed when it is run again. Serialization failures are only raised
+// at REPEATABLE READ and above, see WithinTransactionOptions
+func retryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "40001", "40P01":
+			return true
+		}
+	}
+	return false
+}
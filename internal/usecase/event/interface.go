@@ -2,17 +2,145 @@ package event
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+const (
+	HeaderEventID     = "event-id"
+	HeaderEventType   = "event-type"
+	HeaderContentType = "content-type"
+	HeaderOccurredAt  = "occurred-at"
+	// HeaderTraceParent carries the W3C trace context of the producer's span
+	HeaderTraceParent = "traceparent"
+
+	// headers added to dead-lettered messages
+	HeaderOriginalTopic     = "original-topic"
+	HeaderOriginalPartition = "original-partition"
+	HeaderOriginalOffset    = "original-offset"
+	HeaderError             = "error"
+	HeaderAttempts          = "attempts"
+)
+
+const (
+	OffsetFirst = "first"
+	OffsetLast  = "last"
 )
 
 type ConsumerConfig interface {
 	GetBrokers() []string
 	GetTopic() string
 	GetGroupID() string
-	GetHandler() func(ctx context.Context, key, value []byte) error
+	// GetStartOffset is where a group without committed offsets starts, OffsetFirst or OffsetLast
+	GetStartOffset() string
+	GetHandler() func(ctx context.Context, message Message) error
+	GetRetryPolicy() RetryPolicy
+	// GetWorkers is how many messages are handled at once, messages with
+	// the same key are still handled one by one in order
+	GetWorkers() int
+}
+
+// RetryPolicy tells the consumer how to handle a message its handler fails on
+type RetryPolicy struct {
+	// MaxAttempts counts the first attempt too, values below 1 mean a single attempt
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// DeadLetterTopic receives messages that failed MaxAttempts times,
+	// without it they are logged and skipped
+	DeadLetterTopic string
+}
+
+// Backoff returns the delay before retry number attempt, it doubles with
+// every attempt up to MaxBackoff and is jittered so consumers do not retry in step
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	backoff := p.InitialBackoff
+	for i := 1; i < attempt && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	if backoff <= 0 {
+		return 0
+	}
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
 }
 
 type BrokerConsumer interface {
-	Run() error
+	// Run consumes registered topics until ctx is done or Close is called,
+	// it blocks until every reader exits and reports the readers' errors
+	Run(ctx context.Context) error
 	RegisterConsumer(config ConsumerConfig)
-	Close()
-}
\ No newline at end of file
+	// Close stops fetching and waits for running handlers until ctx is done
+	Close(ctx context.Context) error
+}
+
+// PermanentError marks a message that fails the same way on every attempt,
+// the consumer dead-letters it without retrying
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+func NewPermanentError(err error) error {
+	return &PermanentError{Err: err}
+}
+
+func IsPermanent(err error) bool {
+	var permanentError *PermanentError
+	return errors.As(err, &permanentError)
+}
+
+type Header struct {
+	Key   string
+	Value []byte
+}
+
+// Message is a record written to or read from the broker. Empty Topic
+// means the producer's topic, Partition and Offset are set on read messages
+type Message struct {
+	Topic     string
+	Partition int
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   []Header
+	Time      time.Time
+}
+
+// Header returns value of the header key or empty string
+func (m Message) Header(key string) string {
+	for _, header := range m.Headers {
+		if header.Key == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+// ID identifies the message across redeliveries: the event id header when
+// the producer set one, otherwise the message position in the topic
+func (m Message) ID() string {
+	if id := m.Header(HeaderEventID); id != "" {
+		return id
+	}
+	return fmt.Sprintf("%s/%d/%d", m.Topic, m.Partition, m.Offset)
+}
+
+type BrokerProducer interface {
+	// Produce writes messages in order. A synchronous producer returns once
+	// the broker acknowledged all of them, an asynchronous one only queues them
+	Produce(ctx context.Context, messages ...Message) error
+	// Close flushes queued messages and releases connections
+	Close() error
+}
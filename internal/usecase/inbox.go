@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"fourth-exam/user-service-evrone/internal/infrastructure/repository"
+	"fourth-exam/user-service-evrone/internal/pkg/otlp"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	serviceNameInbox = "inboxService"
+	spanNameInbox    = "inboxUsecase"
+)
+
+type Inbox interface {
+	// Process runs fn once per message of consumer, it reports false when
+	// the message was processed before and fn was skipped
+	Process(ctx context.Context, consumer, messageID string, fn func(ctx context.Context) error) (bool, error)
+	// Prune forgets messages processed more than retention ago
+	Prune(ctx context.Context, retention time.Duration) (int64, error)
+}
+
+type inboxService struct {
+	repo       repository.ProcessedMessage
+	transactor repository.Transactor
+	ctxTimeout time.Duration
+}
+
+func NewInboxService(ctxTimeout time.Duration, repo repository.ProcessedMessage, transactor repository.Transactor) Inbox {
+	return &inboxService{
+		repo:       repo,
+		transactor: transactor,
+		ctxTimeout: ctxTimeout,
+	}
+}
+
+// Process records the message and runs fn in one transaction, so a message
+// redelivered after a crash or rebalance is either fully applied or not at all
+func (i *inboxService) Process(ctx context.Context, consumer, messageID string, fn func(ctx context.Context) error) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, i.ctxTimeout)
+	defer cancel()
+	ctx, span := otlp.Start(ctx, serviceNameInbox, spanNameInbox+"Process")
+	defer span.End()
+
+	span.SetAttributes(attribute.KeyValue{Key: "Inbox -> usecase -> ", Value: attribute.StringValue(messageID)})
+
+	var processed bool
+	err := i.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		added, err := i.repo.Add(ctx, consumer, messageID, time.Now().UTC())
+		if err != nil || !added {
+			processed = false
+			return err
+		}
+
+		processed = true
+		return fn(ctx)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return processed, nil
+}
+
+func (i *inboxService) Prune(ctx context.Context, retention time.Duration) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, i.ctxTimeout)
+	defer cancel()
+	ctx, span := otlp.Start(ctx, serviceNameInbox, spanNameInbox+"Prune")
+	defer span.End()
+
+	span.SetAttributes(attribute.KeyValue{Key: "Inbox -> usecase -> ", Value: attribute.StringValue("Prune processed messages")})
+
+	if retention < 0 {
+		return 0, fmt.Errorf("inbox retention must not be negative: %s", retention)
+	}
+
+	return i.repo.DeleteBefore(ctx, time.Now().UTC().Add(-retention))
+}
@@ -0,0 +1,202 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"fourth-exam/user-service-evrone/internal/entity"
+	"fourth-exam/user-service-evrone/internal/infrastructure/repository"
+	"fourth-exam/user-service-evrone/internal/pkg/codec"
+	"fourth-exam/user-service-evrone/internal/pkg/otlp"
+	"fourth-exam/user-service-evrone/internal/usecase/event"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const (
+	serviceNameOutbox = "outboxService"
+	spanNameOutbox    = "outboxUsecase"
+)
+
+type OutboxRelay interface {
+	// Relay publishes up to limit pending events and returns how many were sent
+	Relay(ctx context.Context, limit uint64) (int, error)
+	// Prune deletes events sent more than retention ago
+	Prune(ctx context.Context, retention time.Duration) (int64, error)
+}
+
+type outboxRelay struct {
+	repo       repository.Outbox
+	transactor repository.Transactor
+	producer   event.BrokerProducer
+	ctxTimeout time.Duration
+}
+
+// NewOutboxRelay needs a synchronous producer, events are marked as sent
+// as soon as Produce returns
+func NewOutboxRelay(ctxTimeout time.Duration, repo repository.Outbox, transactor repository.Transactor, producer event.BrokerProducer) OutboxRelay {
+	return &outboxRelay{
+		repo:       repo,
+		transactor: transactor,
+		producer:   producer,
+		ctxTimeout: ctxTimeout,
+	}
+}
+
+// Relay publishes pending events in the order they were written and marks
+// them as sent in the same transaction. Only the relay holding the outbox
+// lock publishes, so events of a user never overtake each other; a crash
+// between publishing and commit sends the batch again
+func (o *outboxRelay) Relay(ctx context.Context, limit uint64) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, o.ctxTimeout)
+	defer cancel()
+	ctx, span := otlp.Start(ctx, serviceNameOutbox, spanNameOutbox+"Relay")
+	defer span.End()
+
+	span.SetAttributes(attribute.KeyValue{Key: "Outbox -> usecase -> ", Value: attribute.StringValue("Relay events")})
+
+	var sent int
+	err := o.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		sent = 0
+
+		locked, err := o.repo.TryLock(ctx)
+		if err != nil || !locked {
+			return err
+		}
+
+		events, err := o.repo.FetchPending(ctx, limit)
+		if err != nil || len(events) == 0 {
+			return err
+		}
+
+		messages := make([]event.Message, 0, len(events))
+		ids := make([]int64, 0, len(events))
+		for _, outboxEvent := range events {
+			messages = append(messages, outboxMessage(outboxEvent))
+			ids = append(ids, outboxEvent.ID)
+		}
+		if err = o.producer.Produce(ctx, messages...); err != nil {
+			return err
+		}
+
+		if err = o.repo.MarkSent(ctx, ids, time.Now().UTC()); err != nil {
+			return err
+		}
+
+		sent = len(events)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return sent, nil
+}
+
+// Prune keeps the outbox from growing without bound, unsent events are kept
+// whatever their age
+func (o *outboxRelay) Prune(ctx context.Context, retention time.Duration) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, o.ctxTimeout)
+	defer cancel()
+	ctx, span := otlp.Start(ctx, serviceNameOutbox, spanNameOutbox+"Prune")
+	defer span.End()
+
+	span.SetAttributes(attribute.KeyValue{Key: "Outbox -> usecase -> ", Value: attribute.StringValue("Prune sent events")})
+
+	if retention < 0 {
+		return 0, fmt.Errorf("outbox retention must not be negative: %s", retention)
+	}
+
+	return o.repo.DeleteSentBefore(ctx, time.Now().UTC().Add(-retention))
+}
+
+// outboxMessage keys the event by aggregate id, so events of a user are
+// consumed in the order they happened. Trace context of the change goes
+// along, so consumers join the trace of the request that made it
+func outboxMessage(outboxEvent *entity.OutboxEvent) event.Message {
+	headers := []event.Header{
+		{Key: event.HeaderEventID, Value: []byte(outboxEvent.EventID)},
+		{Key: event.HeaderEventType, Value: []byte(outboxEvent.EventType)},
+		{Key: event.HeaderContentType, Value: []byte(codec.ContentTypeJSON)},
+		{Key: event.HeaderOccurredAt, Value: []byte(outboxEvent.CreatedAt.Format(time.RFC3339Nano))},
+	}
+	for key, value := range outboxEvent.TraceContext {
+		headers = append(headers, event.Header{Key: key, Value: []byte(value)})
+	}
+
+	return event.Message{
+		Key:     []byte(outboxEvent.AggregateID),
+		Value:   outboxEvent.Payload,
+		Headers: headers,
+		Time:    outboxEvent.CreatedAt,
+	}
+}
+
+// userEventPayload is the body of user events, deleted events carry only
+// the id, deletion time and version
+type userEventPayload struct {
+	Id        string     `json:"id"`
+	Username  string     `json:"username,omitempty"`
+	Email     string     `json:"email,omitempty"`
+	FirstName string     `json:"first_name,omitempty"`
+	LastName  string     `json:"last_name,omitempty"`
+	Bio       string     `json:"bio,omitempty"`
+	Website   string     `json:"website,omitempty"`
+	IsActive  bool       `json:"is_active"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	Version   int64      `json:"version,omitempty"`
+}
+
+func newUserEventPayload(user *entity.User) *userEventPayload {
+	payload := &userEventPayload{
+		Id:        user.Id,
+		Username:  user.Username,
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Bio:       user.Bio,
+		Website:   user.Website,
+		IsActive:  user.IsActive,
+		Version:   user.Version,
+	}
+	for _, t := range []struct {
+		value time.Time
+		field **time.Time
+	}{
+		{user.CreatedAt, &payload.CreatedAt},
+		{user.UpdatedAt, &payload.UpdatedAt},
+		{user.DeletedAt, &payload.DeletedAt},
+	} {
+		if !t.value.IsZero() {
+			value := t.value
+			*t.field = &value
+		}
+	}
+	return payload
+}
+
+// newUserEvent builds an outbox event of user, it has to be added in the
+// transaction of the change it describes
+func newUserEvent(ctx context.Context, eventType string, user *entity.User) (*entity.OutboxEvent, error) {
+	payload, err := codec.JSON{}.Marshal(newUserEventPayload(user))
+	if err != nil {
+		return nil, err
+	}
+
+	traceContext := propagation.MapCarrier{}
+	otlp.InjectTraceContext(ctx, traceContext)
+
+	return &entity.OutboxEvent{
+		EventID:      uuid.New().String(),
+		AggregateID:  user.Id,
+		EventType:    eventType,
+		Payload:      payload,
+		TraceContext: traceContext,
+		CreatedAt:    time.Now().UTC(),
+	}, nil
+}
@@ -2,13 +2,19 @@ package usecase
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
 	"time"
 
 	"fourth-exam/user-service-evrone/internal/entity"
 	"fourth-exam/user-service-evrone/internal/infrastructure/repository"
 	"fourth-exam/user-service-evrone/internal/pkg/otlp"
+	"fourth-exam/user-service-evrone/internal/pkg/password"
 
 	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/text/unicode/norm"
 )
 
 const (
@@ -18,21 +24,41 @@ const (
 
 type User interface {
 	Create(ctx context.Context, req *entity.User) (*entity.User, error)
+	Upsert(ctx context.Context, req *entity.User) (*entity.User, error)
 	Get(ctx context.Context, params map[string]string) (*entity.User, error)
-	List(ctx context.Context, req *entity.GetListFilter) ([]*entity.User, error)
-	Update(ctx context.Context, req *entity.User) error
-	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, req *entity.GetListFilter) (*entity.UserList, error)
+	Update(ctx context.Context, req *entity.User, fields []string) (*entity.User, error)
+	Delete(ctx context.Context, id string, version int64) error
+	CheckField(ctx context.Context, field, value string) (bool, error)
+	UpdateRefreshToken(ctx context.Context, id, refreshToken string) error
+	Restore(ctx context.Context, id string) error
+	Purge(ctx context.Context, retention time.Duration) (int64, error)
+	VerifyCredentials(ctx context.Context, login, password string) (*entity.User, error)
 }
 
+const (
+	FieldID       = "id"
+	FieldUsername = "username"
+	FieldEmail    = "email"
+)
+
 type userService struct {
 	BaseUseCase
 	repo       repository.User
+	outbox     repository.Outbox
+	transactor repository.Transactor
+	hasher     *password.Hasher
 	ctxTimeout time.Duration
 }
 
-func NewUserService(ctxTimeout time.Duration, repo repository.User) User {
+// NewUserService hashes passwords with hasher before a transaction starts,
+// so slow hashing never holds a connection
+func NewUserService(ctxTimeout time.Duration, repo repository.User, outbox repository.Outbox, transactor repository.Transactor, hasher *password.Hasher) User {
 	return &userService{
 		repo:       repo,
+		outbox:     outbox,
+		transactor: transactor,
+		hasher:     hasher,
 		ctxTimeout: ctxTimeout,
 	}
 }
@@ -47,7 +73,112 @@ func (u *userService) Create(ctx context.Context, req *entity.User) (*entity.Use
 
 	u.beforeRequest(&req.Id, &req.CreatedAt, &req.UpdatedAt)
 
-	return u.repo.Create(ctx, req)
+	req.Username = normalizeField(FieldUsername, req.Username)
+	req.Email = normalizeField(FieldEmail, req.Email)
+
+	v := newValidator()
+	v.user(req)
+	// a user without password could never log in
+	v.password("password", req.Password)
+	if err := v.err("invalid user"); err != nil {
+		return nil, err
+	}
+
+	if req.RefreshToken != "" {
+		req.RefreshToken = hashRefreshToken(req.RefreshToken)
+	}
+
+	passwordHash, err := u.hasher.Hash(req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	var created *entity.User
+	err = u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		user, err := u.repo.Create(ctx, req, passwordHash)
+		if err != nil {
+			return err
+		}
+		created = user
+		return u.addEvent(ctx, entity.EventUserCreated, user)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// Upsert creates the user or overwrites its profile when a user with the
+// same id exists, so applying the same user twice is harmless
+func (u *userService) Upsert(ctx context.Context, req *entity.User) (*entity.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.ctxTimeout)
+	defer cancel()
+	ctx, span := otlp.Start(ctx, serviceNameUser, spanNameUser+"Upsert")
+	defer span.End()
+
+	span.SetAttributes(attribute.KeyValue{Key: "User -> usecase -> ", Value: attribute.StringValue("Upserting user")})
+
+	u.beforeRequest(&req.Id, &req.CreatedAt, &req.UpdatedAt)
+
+	req.Username = normalizeField(FieldUsername, req.Username)
+	req.Email = normalizeField(FieldEmail, req.Email)
+
+	v := newValidator()
+	v.user(req)
+	// user events carry no password, one given is still held to the policy
+	if req.Password != "" {
+		v.password("password", req.Password)
+	}
+	if err := v.err("invalid user"); err != nil {
+		return nil, err
+	}
+
+	if req.RefreshToken != "" {
+		req.RefreshToken = hashRefreshToken(req.RefreshToken)
+	}
+
+	var passwordHash string
+	if req.Password != "" {
+		hash, err := u.hasher.Hash(req.Password)
+		if err != nil {
+			return nil, err
+		}
+		passwordHash = hash
+	}
+
+	var stored *entity.User
+	err := u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		created, err := u.repo.Upsert(ctx, req, passwordHash)
+		if err != nil {
+			return err
+		}
+
+		user, err := u.repo.Get(ctx, map[string]string{FieldID: req.Id})
+		if err != nil {
+			return err
+		}
+		stored = user
+
+		if created {
+			return u.addEvent(ctx, entity.EventUserCreated, user)
+		}
+		return u.addEvent(ctx, entity.EventUserUpdated, user)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stored, nil
+}
+
+// addEvent stores user event in the outbox within the transaction of ctx
+func (u *userService) addEvent(ctx context.Context, eventType string, user *entity.User) error {
+	event, err := newUserEvent(ctx, eventType, user)
+	if err != nil {
+		return u.Error("encode "+eventType+" event", err)
+	}
+	return u.outbox.Add(ctx, event)
 }
 
 func (u *userService) Get(ctx context.Context, params map[string]string) (*entity.User, error) {
@@ -58,10 +189,38 @@ func (u *userService) Get(ctx context.Context, params map[string]string) (*entit
 
 	span.SetAttributes(attribute.KeyValue{Key: "User -> usecase -> ", Value: attribute.StringValue("Getting user")})
 
-	return u.repo.Get(ctx, params)
+	filter := make(map[string]string, 1)
+	for key, value := range params {
+		if value = normalizeField(key, value); value != "" {
+			filter[key] = value
+		}
+	}
+
+	v := newValidator()
+	switch len(filter) {
+	case 0:
+		v.add("user_id", "one of user_id, email or username is required")
+	case 1:
+		for key := range filter {
+			switch key {
+			case FieldID, FieldUsername, FieldEmail:
+			default:
+				v.add(key, "user can not be looked up by this field")
+			}
+		}
+	default:
+		for key := range filter {
+			v.add(requestField(key), "only one of user_id, email or username may be given")
+		}
+	}
+	if err := v.err("invalid get user request"); err != nil {
+		return nil, err
+	}
+
+	return u.repo.Get(ctx, filter)
 }
 
-func (u *userService) List(ctx context.Context, req *entity.GetListFilter) ([]*entity.User, error) {
+func (u *userService) List(ctx context.Context, req *entity.GetListFilter) (*entity.UserList, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.ctxTimeout)
 	defer cancel()
 	ctx, span := otlp.Start(ctx, serviceNameUser, spanNameUser+"List")
@@ -69,10 +228,34 @@ func (u *userService) List(ctx context.Context, req *entity.GetListFilter) ([]*e
 
 	span.SetAttributes(attribute.KeyValue{Key: "User -> usecase -> ", Value: attribute.StringValue("Get list")})
 
+	req.Search = strings.TrimSpace(req.Search)
+
+	v := newValidator()
+	v.check(req.Page >= 0, "page", "must not be negative")
+	v.check(req.Limit >= 0, "limit", "must not be negative")
+	if !req.CreatedFrom.IsZero() && !req.CreatedTo.IsZero() {
+		v.check(req.CreatedFrom.Before(req.CreatedTo), "created_to", "must be after created_from")
+	}
+	sort, problems := parseOrderBy(req.OrderBy, u.repo.CanSortBy)
+	for _, problem := range problems {
+		v.add("order_by", problem)
+	}
+	req.Sort = sort
+	if err := v.err("invalid list users request"); err != nil {
+		return nil, err
+	}
+	if req.Page == 0 {
+		req.Page = 1
+	}
+
 	return u.repo.List(ctx, req)
 }
 
-func (u *userService) Update(ctx context.Context, req *entity.User) error {
+// Update writes only the listed fields of req and returns the stored user.
+// Without fields every non-empty field is written and is_active only when it
+// is true, as false can not be told from an unset value; callers that mean
+// to deactivate list is_active explicitly
+func (u *userService) Update(ctx context.Context, req *entity.User, fields []string) (*entity.User, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.ctxTimeout)
 	defer cancel()
 	ctx, span := otlp.Start(ctx, serviceNameUser, spanNameUser+"Update")
@@ -80,12 +263,94 @@ func (u *userService) Update(ctx context.Context, req *entity.User) error {
 
 	span.SetAttributes(attribute.KeyValue{Key: "User -> usecase -> ", Value: attribute.StringValue("Update user")})
 
-	u.beforeRequest(&req.Id, &req.CreatedAt, &req.UpdatedAt)
+	v := newValidator()
+	v.check(strings.TrimSpace(req.Id) != "", "user.id", "must not be empty")
 
-	return u.repo.Update(ctx, req)
+	if len(fields) == 0 {
+		fields = PopulatedFields(req)
+		if req.IsActive {
+			fields = append(fields, "is_active")
+		}
+		v.check(len(fields) != 0, "update_mask", "nothing to update")
+	}
+
+	seen := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		if seen[field] {
+			continue
+		}
+		seen[field] = true
+
+		switch field {
+		case FieldUsername:
+			req.Username = normalizeField(FieldUsername, req.Username)
+			v.username("user.username", req.Username)
+		case FieldEmail:
+			req.Email = normalizeField(FieldEmail, req.Email)
+			v.email("user.email", req.Email)
+		case "first_name":
+			v.name("user.first_name", req.FirstName)
+		case "last_name":
+			v.name("user.last_name", req.LastName)
+		case "bio":
+			v.bio("user.bio", req.Bio)
+		case "website":
+			v.website("user.website", req.Website)
+		case "is_active":
+		default:
+			v.add("update_mask", fmt.Sprintf("unknown or read-only path %q", field))
+		}
+	}
+	if err := v.err("invalid update user request"); err != nil {
+		return nil, err
+	}
+
+	mask := make([]string, 0, len(seen))
+	for field := range seen {
+		mask = append(mask, field)
+	}
+	req.UpdatedAt = time.Now().UTC()
+
+	var updated *entity.User
+	err := u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := u.repo.Update(ctx, req, mask); err != nil {
+			return err
+		}
+
+		user, err := u.repo.Get(ctx, map[string]string{FieldID: req.Id})
+		if err != nil {
+			return err
+		}
+		updated = user
+		return u.addEvent(ctx, entity.EventUserUpdated, user)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// PopulatedFields lists updatable text fields of user holding non-empty values
+func PopulatedFields(user *entity.User) []string {
+	var fields []string
+	for field, value := range map[string]string{
+		FieldUsername: user.Username,
+		FieldEmail:    user.Email,
+		"first_name":  user.FirstName,
+		"last_name":   user.LastName,
+		"bio":         user.Bio,
+		"website":     user.Website,
+	} {
+		if value != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
 }
 
-func (u *userService) Delete(ctx context.Context, id string) error {
+// Delete soft deletes the user, version 0 skips the concurrent change check
+func (u *userService) Delete(ctx context.Context, id string, version int64) error {
 	ctx, cancel := context.WithTimeout(ctx, u.ctxTimeout)
 	defer cancel()
 	ctx, span := otlp.Start(ctx, serviceNameUser, spanNameUser+"Delete")
@@ -93,5 +358,198 @@ func (u *userService) Delete(ctx context.Context, id string) error {
 
 	span.SetAttributes(attribute.KeyValue{Key: "User -> usecase -> ", Value: attribute.StringValue("Delete user")})
 
-	return u.repo.Delete(ctx, id)
+	return u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		deleted, err := u.repo.Delete(ctx, id, version)
+		if err != nil {
+			return err
+		}
+		// the version orders the event after the updates of the user
+		return u.addEvent(ctx, entity.EventUserDeleted, deleted)
+	})
+}
+
+func (u *userService) Restore(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, u.ctxTimeout)
+	defer cancel()
+	ctx, span := otlp.Start(ctx, serviceNameUser, spanNameUser+"Restore")
+	defer span.End()
+
+	span.SetAttributes(attribute.KeyValue{Key: "User -> usecase -> ", Value: attribute.StringValue("Restore user")})
+
+	return u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := u.repo.Restore(ctx, id); err != nil {
+			return err
+		}
+
+		user, err := u.repo.Get(ctx, map[string]string{FieldID: id})
+		if err != nil {
+			return err
+		}
+		return u.addEvent(ctx, entity.EventUserUpdated, user)
+	})
+}
+
+// Purge hard deletes users soft deleted more than retention ago
+func (u *userService) Purge(ctx context.Context, retention time.Duration) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.ctxTimeout)
+	defer cancel()
+	ctx, span := otlp.Start(ctx, serviceNameUser, spanNameUser+"Purge")
+	defer span.End()
+
+	span.SetAttributes(attribute.KeyValue{Key: "User -> usecase -> ", Value: attribute.StringValue("Purge users")})
+
+	if retention < 0 {
+		return 0, fmt.Errorf("purge retention must not be negative: %s", retention)
+	}
+
+	return u.repo.Purge(ctx, time.Now().UTC().Add(-retention))
+}
+
+// VerifyCredentials returns the user when login (username or email) and
+// password match, any failure is reported as entity.ErrorInvalidCredentials
+func (u *userService) VerifyCredentials(ctx context.Context, login, password string) (*entity.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.ctxTimeout)
+	defer cancel()
+	ctx, span := otlp.Start(ctx, serviceNameUser, spanNameUser+"VerifyCredentials")
+	defer span.End()
+
+	span.SetAttributes(attribute.KeyValue{Key: "User -> usecase -> ", Value: attribute.StringValue("Verify credentials")})
+
+	field := FieldUsername
+	if strings.Contains(login, "@") {
+		field = FieldEmail
+	}
+
+	login = normalizeField(field, login)
+	if login == "" || password == "" {
+		return nil, entity.ErrorInvalidCredentials
+	}
+
+	return u.repo.VerifyCredentials(ctx, field, login, password)
+}
+
+// CheckField reports whether the given username or email is still available
+func (u *userService) CheckField(ctx context.Context, field, value string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.ctxTimeout)
+	defer cancel()
+	ctx, span := otlp.Start(ctx, serviceNameUser, spanNameUser+"CheckField")
+	defer span.End()
+
+	span.SetAttributes(attribute.KeyValue{Key: "User -> usecase -> ", Value: attribute.StringValue("Check field")})
+
+	field = strings.ToLower(strings.TrimSpace(field))
+	value = normalizeField(field, value)
+
+	v := newValidator()
+	v.check(field == FieldUsername || field == FieldEmail, "field", "must be one of: username, email")
+	v.check(value != "", "value", "must not be empty")
+	if err := v.err("invalid check field request"); err != nil {
+		return false, err
+	}
+
+	exists, err := u.repo.CheckField(ctx, field, value)
+	if err != nil {
+		return false, err
+	}
+
+	return !exists, nil
+}
+
+// UpdateRefreshToken rotates the user's refresh token, only its hash is stored
+func (u *userService) UpdateRefreshToken(ctx context.Context, id, refreshToken string) error {
+	ctx, cancel := context.WithTimeout(ctx, u.ctxTimeout)
+	defer cancel()
+	ctx, span := otlp.Start(ctx, serviceNameUser, spanNameUser+"UpdateRefreshToken")
+	defer span.End()
+
+	span.SetAttributes(attribute.KeyValue{Key: "User -> usecase -> ", Value: attribute.StringValue("Update refresh token")})
+
+	v := newValidator()
+	v.check(strings.TrimSpace(id) != "", "user_id", "must not be empty")
+	v.check(refreshToken != "", "refresh_token", "must not be empty")
+	if err := v.err("invalid update refresh token request"); err != nil {
+		return err
+	}
+
+	return u.repo.UpdateRefreshToken(ctx, id, hashRefreshToken(refreshToken), time.Now().UTC())
+}
+
+// hashRefreshToken returns hex encoded sha256 of the token. Refresh tokens are
+// long random strings, so a fast digest is enough to keep them out of the database
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// defaultSort is used when List request has no order
+var defaultSort = []entity.SortField{{Field: "created_at", Desc: true}}
+
+// parseOrderBy parses comma separated "field [asc|desc]" list,
+// e.g. "created_at desc, username", and describes every invalid part.
+// sortable tells the fields the repository can order by
+func parseOrderBy(orderBy string, sortable func(field string) bool) ([]entity.SortField, []string) {
+	if strings.TrimSpace(orderBy) == "" {
+		return defaultSort, nil
+	}
+
+	var (
+		sort     []entity.SortField
+		problems []string
+		seen     = make(map[string]bool)
+	)
+	for _, part := range strings.Split(orderBy, ",") {
+		tokens := strings.Fields(part)
+		if len(tokens) == 0 || len(tokens) > 2 {
+			problems = append(problems, fmt.Sprintf("%q must be a field optionally followed by asc or desc", strings.TrimSpace(part)))
+			continue
+		}
+
+		field := strings.ToLower(tokens[0])
+		if !sortable(field) {
+			problems = append(problems, fmt.Sprintf("can not order by %q", tokens[0]))
+			continue
+		}
+		if seen[field] {
+			problems = append(problems, fmt.Sprintf("%q is given more than once", field))
+			continue
+		}
+		seen[field] = true
+
+		sortField := entity.SortField{Field: field}
+		if len(tokens) == 2 {
+			switch strings.ToLower(tokens[1]) {
+			case "asc":
+			case "desc":
+				sortField.Desc = true
+			default:
+				problems = append(problems, fmt.Sprintf("direction of %q must be asc or desc", field))
+				continue
+			}
+		}
+		sort = append(sort, sortField)
+	}
+
+	return sort, problems
+}
+
+// requestField names params key the way clients send it
+func requestField(key string) string {
+	if key == FieldID {
+		return "user_id"
+	}
+	return key
+}
+
+// normalizeField brings user identities to the form they are stored in:
+// emails are lower cased and usernames are NFKC normalized, so look-alike
+// compatibility characters can not register a second "admin"
+func normalizeField(field, value string) string {
+	value = strings.TrimSpace(value)
+	switch field {
+	case FieldEmail:
+		value = strings.ToLower(value)
+	case FieldUsername:
+		value = strings.TrimSpace(norm.NFKC.String(value))
+	}
+	return value
 }
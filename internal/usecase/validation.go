@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"errors"
+	"fourth-exam/user-service-evrone/internal/entity"
+	"net/mail"
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	usernameMinLength = 3
+	usernameMaxLength = 32
+	emailMaxLength    = 254
+	nameMaxLength     = 64
+	websiteMaxLength  = 2048
+	bioMaxLength      = 1000
+	passwordMinLength = 8
+	// bcrypt ignores everything after 72 bytes
+	passwordMaxBytes = 72
+)
+
+// validator collects every field violation of a request, so clients get all
+// problems in one round trip instead of fixing them one by one
+type validator struct {
+	errors map[string]string
+}
+
+func newValidator() *validator {
+	return &validator{errors: make(map[string]string)}
+}
+
+// add records violation of field, descriptions of the same field are joined
+func (v *validator) add(field, description string) {
+	if prev, ok := v.errors[field]; ok {
+		description = prev + "; " + description
+	}
+	v.errors[field] = description
+}
+
+// check records violation of field when ok is false
+func (v *validator) check(ok bool, field, description string) {
+	if !ok {
+		v.add(field, description)
+	}
+}
+
+func (v *validator) valid() bool {
+	return len(v.errors) == 0
+}
+
+// err returns *entity.ErrValidation with collected violations or nil
+func (v *validator) err(message string) error {
+	if v.valid() {
+		return nil
+	}
+	errValidation := entity.NewErrValidation()
+	errValidation.Err = errors.New(message)
+	for field, description := range v.errors {
+		errValidation.Errors[field] = description
+	}
+	return errValidation
+}
+
+// user validates profile fields of a user being created, empty optional
+// fields are skipped. The password is checked by callers, it is required
+// on sign up but absent from user events
+func (v *validator) user(user *entity.User) {
+	v.username("username", user.Username)
+	v.email("email", user.Email)
+	v.name("first_name", user.FirstName)
+	v.name("last_name", user.LastName)
+	v.bio("bio", user.Bio)
+	v.website("website", user.Website)
+}
+
+func (v *validator) username(field, username string) {
+	length := utf8.RuneCountInString(username)
+	if length == 0 {
+		v.add(field, "must not be empty")
+		return
+	}
+	v.check(length >= usernameMinLength && length <= usernameMaxLength, field, "must be 3 to 32 characters long")
+
+	for i, r := range username {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			continue
+		}
+		if i != 0 && (r == '_' || r == '.') {
+			continue
+		}
+		v.add(field, "may contain only letters, digits, '_' and '.' and must start with a letter or digit")
+		return
+	}
+}
+
+func (v *validator) email(field, email string) {
+	if email == "" {
+		v.add(field, "must not be empty")
+		return
+	}
+	if len(email) > emailMaxLength {
+		v.add(field, "must be at most 254 bytes long")
+		return
+	}
+
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email || !strings.Contains(email[strings.LastIndex(email, "@")+1:], ".") {
+		v.add(field, "must be a valid email address")
+	}
+}
+
+func (v *validator) name(field, name string) {
+	v.check(utf8.RuneCountInString(name) <= nameMaxLength, field, "must be at most 64 characters long")
+	v.check(printable(name), field, "must not contain control characters")
+}
+
+func (v *validator) bio(field, bio string) {
+	v.check(utf8.RuneCountInString(bio) <= bioMaxLength, field, "must be at most 1000 characters long")
+}
+
+func (v *validator) website(field, website string) {
+	if website == "" {
+		return
+	}
+	if len(website) > websiteMaxLength {
+		v.add(field, "must be at most 2048 bytes long")
+		return
+	}
+
+	u, err := url.ParseRequestURI(website)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.add(field, "must be an absolute http or https URL")
+	}
+}
+
+func (v *validator) password(field, password string) {
+	if password == "" {
+		v.add(field, "must not be empty")
+		return
+	}
+	if utf8.RuneCountInString(password) < passwordMinLength {
+		v.add(field, "must be at least 8 characters long")
+	}
+	if len(password) > passwordMaxBytes {
+		v.add(field, "must be at most 72 bytes long")
+	}
+
+	var hasLetter, hasDigit bool
+	for _, r := range password {
+		hasLetter = hasLetter || unicode.IsLetter(r)
+		hasDigit = hasDigit || unicode.IsDigit(r)
+	}
+	v.check(hasLetter && hasDigit, field, "must contain at least one letter and one digit")
+}
+
+func printable(s string) bool {
+	for _, r := range s {
+		if unicode.IsControl(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,4 @@
+-- identities are unique case-insensitively among users that are not soft deleted,
+-- so a deleted user's email or username can be taken again
+CREATE UNIQUE INDEX IF NOT EXISTS users_email_unique_idx ON users (lower(email)) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS users_username_unique_idx ON users (lower(username)) WHERE deleted_at IS NULL;
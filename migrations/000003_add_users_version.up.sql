@@ -0,0 +1,2 @@
+-- version is bumped on every change of a user and exposed to clients as etag
+ALTER TABLE users ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS outbox (
+       id BIGSERIAL PRIMARY KEY,
+       event_id uuid NOT NULL UNIQUE,
+       aggregate_id uuid NOT NULL,
+       event_type TEXT NOT NULL,
+       payload BYTEA NOT NULL,
+       created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+       sent_at TIMESTAMP WITHOUT TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS outbox_unsent_idx ON outbox (id) WHERE sent_at IS NULL;
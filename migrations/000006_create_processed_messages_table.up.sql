@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS processed_messages (
+       consumer TEXT NOT NULL,
+       message_id TEXT NOT NULL,
+       processed_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+       PRIMARY KEY (consumer, message_id)
+);
+
+CREATE INDEX IF NOT EXISTS processed_messages_processed_at_idx ON processed_messages (processed_at);